@@ -57,6 +57,7 @@ func init() {
 
 	// Assign the TUI start function to the cmd package variable
 	cmd.StartTUI = startTUI
+	cmd.PickTorrents = tui.PickTorrents
 }
 
 // Function to start TUI. This will be called only if no commands or flags are passed.
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"seedr/internal"
+
+	"github.com/spf13/cobra"
+)
+
+// accountCmd represents the account command
+var accountCmd = &cobra.Command{
+	Use:   "account",
+	Short: "Change the account name or password",
+	Long: `These commands change your Seedr.cc account name and password. Passwords are
+prompted for without echo; when stdin is not a terminal they are read from it,
+one per line.`,
+}
+
+// accountRenameCmd represents the account rename command
+var accountRenameCmd = &cobra.Command{
+	Use:   "rename <new-name>",
+	Short: "Change the account name",
+	Long: `This command changes the name of your account. Your current password is
+required and is prompted for.
+
+Example:
+  seedr account rename "Jane Doe"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running account rename command...")
+		ctx := context.Background()
+
+		if len(args) != 1 {
+			fmt.Println("Please specify the new account name.")
+			cmd.Help()
+			return
+		}
+
+		password, err := readPassword("Current password: ")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		result, err := internal.Account.ChangeName(ctx, args[0], password)
+		if err != nil {
+			fmt.Printf("Error changing account name: %v\n", err)
+			return
+		}
+		if !result.Result {
+			fmt.Println("The account name was not changed; check your password.")
+			return
+		}
+		fmt.Printf("Account name changed to '%s'.\n", args[0])
+	},
+}
+
+// accountPasswdCmd represents the account passwd command
+var accountPasswdCmd = &cobra.Command{
+	Use:   "passwd",
+	Short: "Change the account password",
+	Long: `This command changes your account password. The current password and the new
+password (twice) are prompted for.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running account passwd command...")
+		ctx := context.Background()
+
+		oldPassword, err := readPassword("Current password: ")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		newPassword, err := readPassword("New password: ")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if newPassword == "" {
+			fmt.Println("Error: the new password must not be empty.")
+			return
+		}
+		repeated, err := readPassword("Repeat new password: ")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if repeated != newPassword {
+			fmt.Println("Error: the new passwords do not match.")
+			return
+		}
+
+		result, err := internal.Account.ChangePassword(ctx, oldPassword, newPassword)
+		if err != nil {
+			fmt.Printf("Error changing password: %v\n", err)
+			return
+		}
+		if !result.Result {
+			fmt.Println("The password was not changed; check your current password.")
+			return
+		}
+		fmt.Println("Password changed.")
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(accountCmd)
+	accountCmd.AddCommand(accountRenameCmd, accountPasswdCmd)
+}
@@ -1,153 +1,656 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"regexp" // Added for magnet link detection
 	"strings"
+	"sync"
+	"text/tabwriter"
 
 	"seedr/internal"
-
+	"seedr/pkg/seedr"
 
 	"github.com/spf13/cobra"
 )
 
 // addCmd represents the add command
 var addCmd = &cobra.Command{
-	Use:     "add <torrent-source>",
+	Use:     "add <torrent-source>...",
 	Aliases: []string{"a"},
-	Short:   "Add a torrent to Seedr (magnet link, .torrent file, or URL to scan)",
-	Long: `This command allows you to add a torrent to your Seedr.cc account.
-You can provide a magnet link, a path to a local .torrent file,
-or a URL to a webpage to scan for torrents. The command will automatically
-detect the type of input.
+	Short:   "Add torrents to Seedr (magnet links, .torrent files, or URLs to scan)",
+	Long: `This command allows you to add torrents to your Seedr.cc account.
+You can provide magnet links, paths to local .torrent files, links to remote
+.torrent files, or URLs of webpages to scan for torrents. The command will
+automatically detect the type of each input.
+
+Several sources can be given at once. Use "-" to read free text from stdin, or
+--from-file to read it from a file: every magnet: URI and .torrent link in the
+text is added, as is every line that is a path to a local .torrent file.
+Sources are added with bounded concurrency (--jobs) and a summary shows which
+were added, already present, queued to the wishlist, or failed. Magnet links
+and .torrent files are parsed first: the name and total size are shown before
+uploading, and torrents whose infohash is already in the account are skipped.
+
+Before submitting, the declared size of each torrent is compared with the free
+space on the account and a warning is printed if it will not fit. With
+--make-room, the oldest top-level folders that would free enough space are
+listed and deleted after confirmation.
 
 The target directory can optionally be specified using the --td flag.
 
+When scanning a page, the torrents to add can be chosen without a prompt:
+  --select 1,3-5     pick results by their number in the scan list
+  --all              add every result
+  --best             add the result with the highest health (pct)
+  --match <regex>    only consider results whose title matches
+  --min-size/--max-size  only consider results within a size range
+Filters without --select, --all or --best add every remaining result.
+Without any of these flags, an interactive picker is shown when stdin is a
+terminal: type / to filter, space to select several torrents, enter to add.
+Use --list to print the scan results as JSON without adding anything.
+
 Examples:
   seedr add "magnet:?xt=urn:btih:..."
   seedr add /path/to/my.torrent --td Movies
-  seedr add "https://example.com/page-with-torrents"`,
+  seedr add a.torrent b.torrent "magnet:?xt=urn:btih:..."
+  seedr add --from-file notes.txt --jobs 8
+  seedr add big.torrent --make-room
+  pbpaste | seedr add -
+  seedr add "https://example.com/page-with-torrents"
+  seedr add "https://example.com/page" --match '1080p' --best
+  seedr add "https://example.com/page" --select 1,3-5 --max-size 4GB
+  seedr add "https://example.com/page" --list`,
 	Run: func(cmd *cobra.Command, args []string) {
 		internal.Log.Debug("Running add command...")
 		ctx := context.Background()
 
-		if len(args) != 1 {
+		inputs, err := collectAddInputs(args)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if len(inputs) == 0 {
 			fmt.Println("Please provide a magnet link, a .torrent file path, or a URL to scan.")
 			cmd.Help()
 			return
 		}
 
-		input := args[0]
-		var magnetLink *string
-		var torrentFileContent []byte
-		var err error
-
-		// Regex to detect magnet links
-		isMagnet, err := regexp.MatchString("^magnet:.*", input)
-		if err != nil {
-			fmt.Printf("Error checking magnet link regex: %v\n", err)
+		if addListScan {
+			for _, input := range inputs {
+				if src := classifySource(input); src.kind == sourcePage {
+					listScanResults(ctx, src.input)
+				}
+			}
 			return
 		}
 
-		if isMagnet {
-			magnetLink = &input
-			internal.Log.Debug("Detected input as magnet link: %s", *magnetLink)
-		} else if strings.HasSuffix(strings.ToLower(input), ".torrent") {
-			// Handle .torrent file upload
-			fileBytes, err := os.ReadFile(input)
-			if err != nil {
-				fmt.Printf("Error reading torrent file '%s': %v\n", input, err)
-				return
-			}
-			torrentFileContent = fileBytes
-			internal.Log.Debug("Detected input as local torrent file: %s", input)
-		} else {
-			// Assume it's a URL to scan
-			internal.Log.Debug("Detected input as URL to scan for torrents: %s", input)
-			scanResult, err := internal.Account.ScanPage(ctx, input)
-			if err != nil {
-				fmt.Printf("Error scanning URL '%s': %v\n", input, err)
-				return
-			}
-
-			if len(scanResult.Torrents) == 0 {
-				fmt.Printf("No torrents found on page '%s'.\n", input)
-				return
-			}
+		folderID, ok := resolveTargetFolder()
+		if !ok {
+			return
+		}
 
-			// Simple TUI for selection
-			fmt.Println("Torrents found on page:")
-			for i, t := range scanResult.Torrents {
-				fmt.Printf("[%d] %s (Size: %s, Magnet: %s)\n", i+1, t.Title, internal.HumanReadableBytes(t.Size), t.Magnet)
+		var queue []addSource
+		for _, input := range inputs {
+			src := classifySource(input)
+			if src.kind != sourcePage {
+				queue = append(queue, src)
+				continue
 			}
-			fmt.Print("Enter the number of the torrent to add (or 0 to cancel): ")
-			var selection int
-			_, err = fmt.Scanln(&selection)
-			if err != nil || selection < 0 || selection > len(scanResult.Torrents) {
-				fmt.Println("Invalid selection. Cancelling add operation.")
-				return
+			for _, t := range chooseFromPage(ctx, src.input) {
+				internal.Log.Debug("Selected torrent from scan: %s", t.Title)
+				queue = append(queue, addSource{kind: sourceMagnet, input: t.Magnet, label: t.Title, size: int64(t.Size)})
 			}
-			if selection == 0 {
-				fmt.Println("Add operation cancelled.")
-				return
-			}
-			selectedTorrent := scanResult.Torrents[selection-1]
-			magnetLink = &selectedTorrent.Magnet
-			internal.Log.Debug("Selected torrent from scan: %s", selectedTorrent.Title)
-		}
-
-		// Determine target folder ID
-		folderID := "-1" // Default to root
-		if targetDirectoryName != "" {
-			_, err := FetchObjectDetails() // Ensure cache is populated
-			if err != nil {
-				fmt.Printf("Error fetching Seedr objects for folder lookup: %v\n", err)
-				return
-			}
-			obj, ok := allSeedrObjects[targetDirectoryName]
-			if !ok || !obj.isDir {
-				fmt.Printf("Error: Directory '%s' not found or is not a directory.\n", targetDirectoryName)
-				return
-			}
-			folderID = obj.id
-			internal.Log.Debug("Adding to directory: %s (ID: %s)", targetDirectoryName, folderID)
 		}
-
-		addResult, err := internal.Account.AddTorrent(ctx, magnetLink, torrentFileContent, nil, folderID)
-		if err != nil {
-			if apiErr, ok := err.(*internal.SeedrAPIError); ok {
-				if strings.Contains(apiErr.Message, "not_enough_space_added_to_wishlist") {
-					fmt.Println("Not enough space to add the torrent.")
-					return
-				}
-			}
-			fmt.Printf("Error adding torrent: %v\n", err)
+		if len(queue) == 0 {
 			return
 		}
-		if !addResult.Result && addResult.Code != nil && *addResult.Code == 409 { // Assuming 409 for already added
-			fmt.Println("Torrent already added.")
-		} else if addResult.Result {
-			fmt.Printf("Added '%s' successfully.\n", addResult.Title)
-		} else {
-			fmt.Printf("Failed to add torrent: %v\n", err) // Generic error if not caught above
+
+		outcomes := runAdds(ctx, queue, folderID, addJobs)
+		if len(outcomes) == 1 {
+			printAddOutcome(outcomes[0])
+			return
 		}
+		printAddSummary(outcomes)
 	},
 }
 
 var (
 	targetDirectoryName string
+
+	addFromFiles []string
+	addJobs      int
+	addMakeRoom  bool
+
+	addSelect   string
+	addAll      bool
+	addBest     bool
+	addMatch    string
+	addMinSize  string
+	addMaxSize  string
+	addListScan bool
 )
 
 func init() {
 	RootCmd.AddCommand(addCmd)
 	addCmd.Flags().StringVarP(&targetDirectoryName, "target-directory", "t", "", "Name of the target directory in Seedr (optional)")
+	addCmd.Flags().StringArrayVarP(&addFromFiles, "from-file", "f", nil, "Read magnet links and .torrent links from a text file (repeatable)")
+	addCmd.Flags().IntVarP(&addJobs, "jobs", "j", 4, "Number of torrents to add concurrently")
+	addCmd.Flags().BoolVar(&addMakeRoom, "make-room", false, "Offer to delete the oldest folders when there is not enough free space")
+	addCmd.Flags().StringVar(&addSelect, "select", "", "Scan results to add by number, e.g. 1,3-5")
+	addCmd.Flags().BoolVar(&addAll, "all", false, "Add every scan result")
+	addCmd.Flags().BoolVar(&addBest, "best", false, "Add the scan result with the highest pct")
+	addCmd.Flags().StringVar(&addMatch, "match", "", "Only consider scan results whose title matches this regex")
+	addCmd.Flags().StringVar(&addMinSize, "min-size", "", "Only consider scan results at least this large (e.g. 700MB)")
+	addCmd.Flags().StringVar(&addMaxSize, "max-size", "", "Only consider scan results at most this large (e.g. 4GB)")
+	addCmd.Flags().BoolVar(&addListScan, "list", false, "Print the scan results as JSON without adding anything")
 
 	// Add completion for --td flag
 	addCmd.RegisterFlagCompletionFunc("target-directory", completeFolderPrompt)
 }
 
+// PickTorrents shows the interactive scan-result picker. It is set in cli.go, like StartTUI.
+var PickTorrents func(torrents []seedr.ScannedTorrent) ([]seedr.ScannedTorrent, error)
+
+// addSourceKind describes how a torrent source is submitted.
+type addSourceKind int
+
+const (
+	sourceMagnet      addSourceKind = iota
+	sourceTorrentFile               // Local .torrent file
+	sourceTorrentURL                // Remote .torrent file, downloaded before uploading
+	sourcePage                      // Web page to scan for torrents
+)
+
+// addSource is a single torrent to add.
+type addSource struct {
+	kind  addSourceKind
+	input string // Magnet link, local path or URL
+	label string // Name shown in the summary; defaults to input
+	size  int64  // Size reported by a page scan, if any
+}
+
+// addStatus is the outcome of adding a single torrent.
+type addStatus string
+
+const (
+	addStatusAdded     addStatus = "added"
+	addStatusDuplicate addStatus = "duplicate"
+	addStatusWishlist  addStatus = "wishlist"
+	addStatusFailed    addStatus = "failed"
+)
+
+// addOutcome records what happened to a single source.
+type addOutcome struct {
+	source addSource
+	title  string
+	info   *seedr.TorrentInfo // Parsed metadata, when available
+	status addStatus
+	err    error
+}
+
+var (
+	// Regex to detect magnet links
+	magnetPattern = regexp.MustCompile(`^magnet:.*`)
+
+	// Patterns used to pull sources out of free text
+	magnetInTextPattern      = regexp.MustCompile(`magnet:\?[^\s"'<>]+`)
+	torrentLinkInTextPattern = regexp.MustCompile(`https?://[^\s"'<>]+?\.torrent(?:\?[^\s"'<>]*)?`)
+)
+
+// classifySource detects the kind of a single command-line input.
+func classifySource(input string) addSource {
+	src := addSource{input: input, label: input}
+	lower := strings.ToLower(input)
+	switch {
+	case magnetPattern.MatchString(input):
+		src.kind = sourceMagnet
+	case (strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")) && isTorrentURL(lower):
+		src.kind = sourceTorrentURL
+	case strings.HasSuffix(lower, ".torrent"):
+		src.kind = sourceTorrentFile
+	default:
+		// Assume it's a URL to scan
+		src.kind = sourcePage
+	}
+	return src
+}
+
+// isTorrentURL reports whether a URL points at a .torrent file, ignoring any query string.
+func isTorrentURL(u string) bool {
+	u, _, _ = strings.Cut(u, "#")
+	u, _, _ = strings.Cut(u, "?")
+	return strings.HasSuffix(u, ".torrent")
+}
+
+// collectAddInputs gathers sources from the arguments, stdin ("-") and --from-file.
+func collectAddInputs(args []string) ([]string, error) {
+	var inputs []string
+	for _, arg := range args {
+		if arg != "-" {
+			inputs = append(inputs, arg)
+			continue
+		}
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("error reading stdin: %w", err)
+		}
+		inputs = append(inputs, extractSources(string(data))...)
+	}
+	for _, file := range addFromFiles {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading '%s': %w", file, err)
+		}
+		inputs = append(inputs, extractSources(string(data))...)
+	}
+
+	// Drop duplicates while keeping the original order
+	seen := make(map[string]bool)
+	unique := inputs[:0]
+	for _, input := range inputs {
+		if !seen[input] {
+			seen[input] = true
+			unique = append(unique, input)
+		}
+	}
+	return unique, nil
+}
+
+// extractSources pulls magnet: URIs and .torrent links out of free text.
+// Lines consisting only of a path to an existing local .torrent file are included too.
+func extractSources(text string) []string {
+	var sources []string
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024) // Magnet links with many trackers can be long
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasSuffix(strings.ToLower(line), ".torrent") {
+			if info, err := os.Stat(line); err == nil && !info.IsDir() {
+				sources = append(sources, line)
+				continue
+			}
+		}
+		sources = append(sources, magnetInTextPattern.FindAllString(line, -1)...)
+		sources = append(sources, torrentLinkInTextPattern.FindAllString(line, -1)...)
+	}
+	return sources
+}
+
+// listScanResults prints a page's scan results as JSON, applying the selection filters.
+func listScanResults(ctx context.Context, pageURL string) {
+	scanResult, err := internal.Account.ScanPage(ctx, pageURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error scanning URL '%s': %v\n", pageURL, err)
+		return
+	}
+	selection, err := newScanSelection(len(scanResult.Torrents))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return
+	}
+	if err := printJSON(selection.filter(scanResult.Torrents)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding scan results: %v\n", err)
+	}
+}
+
+// chooseFromPage scans a page and returns the torrents chosen by flags, the picker or the prompt.
+func chooseFromPage(ctx context.Context, pageURL string) []seedr.ScannedTorrent {
+	internal.Log.Debug("Detected input as URL to scan for torrents: %s", pageURL)
+	scanResult, err := internal.Account.ScanPage(ctx, pageURL)
+	if err != nil {
+		fmt.Printf("Error scanning URL '%s': %v\n", pageURL, err)
+		return nil
+	}
+
+	if len(scanResult.Torrents) == 0 {
+		fmt.Printf("No torrents found on page '%s'.\n", pageURL)
+		return nil
+	}
+
+	selection, err := newScanSelection(len(scanResult.Torrents))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return nil
+	}
+
+	if selection.active() {
+		chosen := selection.choose(scanResult.Torrents)
+		if len(chosen) == 0 {
+			fmt.Printf("No torrents on page '%s' match the selection.\n", pageURL)
+		}
+		return chosen
+	}
+
+	if PickTorrents != nil && stdinIsTerminal() {
+		chosen, err := PickTorrents(scanResult.Torrents)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return nil
+		}
+		if len(chosen) == 0 {
+			fmt.Println("Add operation cancelled.")
+		}
+		return chosen
+	}
+
+	selected, ok := promptScannedTorrent(scanResult.Torrents)
+	if !ok {
+		return nil
+	}
+	return []seedr.ScannedTorrent{selected}
+}
+
+// resolveTargetFolder returns the folder ID selected with --target-directory, or the root.
+func resolveTargetFolder() (string, bool) {
+	folderID := "-1" // Default to root
+	if targetDirectoryName != "" {
+		_, err := FetchObjectDetails() // Ensure cache is populated
+		if err != nil {
+			fmt.Printf("Error fetching Seedr objects for folder lookup: %v\n", err)
+			return "", false
+		}
+		obj, ok := allSeedrObjects[targetDirectoryName]
+		if !ok || !obj.isDir {
+			fmt.Printf("Error: Directory '%s' not found or is not a directory.\n", targetDirectoryName)
+			return "", false
+		}
+		folderID = obj.id
+		internal.Log.Debug("Adding to directory: %s (ID: %s)", targetDirectoryName, folderID)
+	}
+	return folderID, true
+}
+
+// runAdds submits every source, running at most jobs requests at a time.
+// Sources are loaded and parsed first so their sizes can be checked against the free space.
+// Outcomes are returned in the same order as the sources.
+func runAdds(ctx context.Context, sources []addSource, folderID string, jobs int) []addOutcome {
+	existing, err := existingTorrentHashes(ctx)
+	if err != nil {
+		// Not fatal: the API still reports duplicates it recognizes itself.
+		internal.Log.Debug("Could not list existing torrents for duplicate detection: %v", err)
+	}
+	verbose := len(sources) == 1
+
+	outcomes := make([]addOutcome, len(sources))
+	prepared := make([]preparedTorrent, len(sources))
+	forEachLimited(len(sources), jobs, func(i int) {
+		outcomes[i].source = sources[i]
+		p, err := prepareTorrent(ctx, sources[i])
+		if err != nil {
+			outcomes[i].status, outcomes[i].err = addStatusFailed, err
+			return
+		}
+		prepared[i], outcomes[i].info = p, p.info
+		if p.info != nil {
+			if name, ok := findExistingTorrent(p.info, existing); ok {
+				outcomes[i].status, outcomes[i].title = addStatusDuplicate, name
+			}
+		}
+	})
+
+	var pending []sizedTorrent
+	for i, o := range outcomes {
+		if o.status == "" {
+			pending = append(pending, sizedTorrent{name: sourceName(o), size: declaredSize(sources[i], prepared[i])})
+		}
+	}
+	preflightSpace(ctx, pending, folderID)
+
+	forEachLimited(len(sources), jobs, func(i int) {
+		if outcomes[i].status != "" {
+			return // Failed to load or already in the account
+		}
+		if verbose && prepared[i].info != nil {
+			fmt.Printf("Adding %s\n", describeTorrentInfo(prepared[i].info))
+		}
+		submitTorrent(ctx, prepared[i], folderID, &outcomes[i])
+	})
+	return outcomes
+}
+
+// forEachLimited calls fn for every index in [0, n), running at most jobs calls at a time.
+func forEachLimited(n, jobs int, fn func(i int)) {
+	if jobs < 1 {
+		jobs = 1
+	}
+	sem := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// declaredSize returns the size a torrent declares: from its parsed metadata, or from the scan result.
+// It returns 0 when the size is unknown, e.g. for magnet links without xl.
+func declaredSize(src addSource, p preparedTorrent) int64 {
+	if p.info != nil && p.info.TotalSize > 0 {
+		return p.info.TotalSize
+	}
+	return src.size
+}
+
+// sourceName returns the best available name for a source.
+func sourceName(o addOutcome) string {
+	if o.title != "" {
+		return o.title
+	}
+	if o.info != nil && o.info.Name != "" {
+		return o.info.Name
+	}
+	return o.source.label
+}
+
+// existingTorrentHashes maps the lowercase infohash of every torrent in the account to its name.
+func existingTorrentHashes(ctx context.Context) (map[string]string, error) {
+	root, err := listFolder(ctx, "0")
+	if err != nil {
+		return nil, err
+	}
+	hashes := make(map[string]string, len(root.Torrents))
+	for _, t := range root.Torrents {
+		if t.Hash != "" {
+			hashes[strings.ToLower(t.Hash)] = t.Name
+		}
+	}
+	return hashes, nil
+}
+
+// findExistingTorrent returns the name of the account torrent with the same infohash, if any.
+func findExistingTorrent(info *seedr.TorrentInfo, existing map[string]string) (string, bool) {
+	for hash, name := range existing {
+		if info.MatchesHash(hash) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// preparedTorrent is a source whose content has been loaded and, where possible, parsed.
+type preparedTorrent struct {
+	magnet  *string
+	content []byte
+	info    *seedr.TorrentInfo // nil if a magnet link could not be parsed
+}
+
+// prepareTorrent reads or downloads a source and parses its metadata.
+func prepareTorrent(ctx context.Context, src addSource) (preparedTorrent, error) {
+	var p preparedTorrent
+	switch src.kind {
+	case sourceMagnet:
+		magnet := src.input
+		p.magnet = &magnet
+		internal.Log.Debug("Detected input as magnet link: %s", magnet)
+		info, err := seedr.ParseMagnet(magnet)
+		if err != nil {
+			// Leave it to the API to decide; it may understand links we don't.
+			internal.Log.Debug("Could not parse magnet link: %v", err)
+			return p, nil
+		}
+		p.info = info
+		return p, nil
+	case sourceTorrentFile:
+		// Handle .torrent file upload
+		fileBytes, err := os.ReadFile(src.input)
+		if err != nil {
+			return p, fmt.Errorf("error reading torrent file '%s': %w", src.input, err)
+		}
+		p.content = fileBytes
+		internal.Log.Debug("Detected input as local torrent file: %s", src.input)
+	case sourceTorrentURL:
+		fileBytes, err := downloadTorrentFile(ctx, src.input)
+		if err != nil {
+			return p, err
+		}
+		p.content = fileBytes
+		internal.Log.Debug("Downloaded remote torrent file: %s", src.input)
+	default:
+		return p, fmt.Errorf("'%s' is not a torrent source", src.input)
+	}
+
+	info, err := seedr.ParseTorrentFile(p.content)
+	if err != nil {
+		return p, fmt.Errorf("'%s': %w", src.input, err)
+	}
+	p.info = info
+	return p, nil
+}
+
+// submitTorrent uploads a prepared magnet link or .torrent file and records the result in outcome.
+func submitTorrent(ctx context.Context, prepared preparedTorrent, folderID string, outcome *addOutcome) {
+	addResult, err := internal.Account.AddTorrent(ctx, prepared.magnet, prepared.content, nil, folderID)
+	if err != nil {
+		if isWishlistedError(err) {
+			outcome.status = addStatusWishlist
+			return
+		}
+		outcome.status, outcome.err = addStatusFailed, fmt.Errorf("error adding torrent: %w", err)
+		return
+	}
+	outcome.title = addResult.Title
+	if !addResult.Result && addResult.Code != nil && *addResult.Code == 409 { // Assuming 409 for already added
+		outcome.status = addStatusDuplicate
+	} else if addResult.Result {
+		outcome.status = addStatusAdded
+	} else {
+		outcome.status, outcome.err = addStatusFailed, fmt.Errorf("the API did not accept the torrent")
+	}
+}
+
+// isWishlistedError reports whether AddTorrent failed because the torrent did not fit and was queued to the wishlist.
+func isWishlistedError(err error) bool {
+	apiErr, ok := err.(*internal.SeedrAPIError)
+	return ok && strings.Contains(apiErr.Message, "not_enough_space_added_to_wishlist")
+}
+
+// describeTorrentInfo formats a torrent's name and total size, when known.
+func describeTorrentInfo(info *seedr.TorrentInfo) string {
+	name := info.Name
+	if name == "" {
+		name = info.InfoHashV1
+		if name == "" {
+			name = info.InfoHashV2
+		}
+	}
+	if info.TotalSize > 0 {
+		return fmt.Sprintf("'%s' (%s)", name, internal.HumanReadableBytes(int(info.TotalSize)))
+	}
+	return fmt.Sprintf("'%s'", name)
+}
+
+// torrentDownloadLimit caps the size of remote .torrent files.
+const torrentDownloadLimit = 10 << 20
+
+// downloadTorrentFile fetches a remote .torrent file so it can be uploaded.
+func downloadTorrentFile(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, internal.Conf.DownloadTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL '%s': %w", url, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading '%s': %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error downloading '%s': %s", url, resp.Status)
+	}
+	// Read one byte past the limit to tell a file of exactly the limit from a larger one
+	data, err := io.ReadAll(io.LimitReader(resp.Body, torrentDownloadLimit+1))
+	if err != nil {
+		return nil, fmt.Errorf("error downloading '%s': %w", url, err)
+	}
+	if len(data) > torrentDownloadLimit {
+		return nil, fmt.Errorf("'%s' exceeds the %s limit for .torrent files", url, internal.HumanReadableBytes(torrentDownloadLimit))
+	}
+	return data, nil
+}
+
+// printAddOutcome prints the result of adding a single torrent.
+func printAddOutcome(o addOutcome) {
+	switch o.status {
+	case addStatusAdded:
+		fmt.Printf("Added '%s' successfully.\n", o.title)
+	case addStatusDuplicate:
+		if o.title != "" {
+			fmt.Printf("Torrent already added as '%s'.\n", o.title)
+		} else {
+			fmt.Println("Torrent already added.")
+		}
+	case addStatusWishlist:
+		fmt.Println("Not enough space to add the torrent; it was queued to the wishlist.")
+	default:
+		fmt.Printf("Failed to add torrent: %v\n", o.err)
+	}
+}
+
+// printAddSummary prints a table of every outcome followed by totals per status.
+func printAddSummary(outcomes []addOutcome) {
+	counts := make(map[addStatus]int)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STATUS\tSIZE\tTORRENT\tDETAILS")
+	for _, o := range outcomes {
+		counts[o.status]++
+		name, size := sourceName(o), "?"
+		if o.info != nil && o.info.TotalSize > 0 {
+			size = internal.HumanReadableBytes(int(o.info.TotalSize))
+		} else if o.source.size > 0 {
+			size = internal.HumanReadableBytes(int(o.source.size))
+		}
+		details := ""
+		if o.err != nil {
+			details = o.err.Error()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", o.status, size, truncateMiddle(name, 60), details)
+	}
+	w.Flush()
+	fmt.Printf("\n%d added, %d duplicate, %d queued to wishlist, %d failed.\n",
+		counts[addStatusAdded], counts[addStatusDuplicate], counts[addStatusWishlist], counts[addStatusFailed])
+}
+
+// truncateMiddle shortens long strings such as magnet links for table output.
+func truncateMiddle(s string, max int) string {
+	if len(s) <= max || max < 5 {
+		return s
+	}
+	half := (max - 3) / 2
+	return s[:half] + "..." + s[len(s)-half:]
+}
+
 func completeFolderPrompt(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	// Only complete folders
 	if len(args) != 0 {
@@ -0,0 +1,426 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"seedr/internal"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show and change the configuration",
+	Long: `The configuration lives in ~/.config/seedr/config.toml (or under
+$XDG_CONFIG_HOME). Profiles other than the default one can override it in
+~/.config/seedr/profiles/<name>/config.toml. Every setting can be overridden
+with an environment variable named after it, e.g. SEEDR_DOWNLOAD_DIR for
+download.dir.
+
+Besides the settings listed by "config get", the default of any command flag
+can be set in a section named after the command, e.g.:
+
+  [add]
+  jobs = 8
+
+  [wishlist.auto]
+  interval = "10m"
+  priority = ["1080p", "S\\d+E\\d+"]
+
+Commands that open files are configured in [handlers], which maps names to
+command templates, and [filetypes], which maps extensions, file types (video,
+audio, image, document) and "default" to handler names:
+
+  [handlers]
+  vlc = "vlc --fullscreen --start-time={start} {url}"
+  mpv = "mpv --title={name} {url}"
+
+Placeholders are {url}, {name}, {path}, {id} and {start}, the saved playback
+position in seconds; arguments with {start} are dropped when there is none.
+
+  [filetypes]
+  video = "mpv"
+  pdf = "browser"`,
+	PersistentPreRunE: skipLogin,
+}
+
+// configGetCmd represents the config get command
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Show settings",
+	Long: `This command shows the effective settings and where they come from. Give a key
+to print only its value.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running config get command...")
+
+		if len(args) == 1 {
+			value, _, ok := configValue(args[0])
+			if !ok {
+				fmt.Fprintf(os.Stderr, "Error: unknown setting '%s'.\n", args[0])
+				return
+			}
+			fmt.Println(value)
+			return
+		}
+
+		type setting struct {
+			Key    string `json:"key"`
+			Value  string `json:"value"`
+			Source string `json:"source"`
+		}
+		var settings []setting
+		for _, key := range configKeys() {
+			value, source, ok := configValue(key)
+			if ok {
+				settings = append(settings, setting{Key: key, Value: value, Source: source})
+			}
+		}
+
+		if outputFormat == "json" {
+			if err := printJSON(settings); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding settings: %v\n", err)
+			}
+			return
+		}
+		for _, s := range settings {
+			fmt.Printf("%s = %s  (%s)\n", s.Key, strconv.Quote(s.Value), s.Source)
+		}
+	},
+	ValidArgsFunction: completeConfigKeys,
+}
+
+// configSetCmd represents the config set command
+var configSetCmd = &cobra.Command{
+	Use:   "set <key=value>...",
+	Short: "Change settings",
+	Long: `This command writes settings to the config file of the active profile, keeping
+the rest of the file as it is. Array values, such as repeated flags, have to be
+set with "config edit".
+
+Examples:
+  seedr config set download.dir=~/Videos player.command="mpv --fs"
+  seedr config set add.jobs=8 list.output=json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running config set command...")
+
+		if len(args) == 0 {
+			fmt.Println("Please specify the settings to change as key=value.")
+			cmd.Help()
+			return
+		}
+
+		path, err := internal.ConfigPath()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+		for _, arg := range args {
+			key, value, ok := strings.Cut(arg, "=")
+			if !ok {
+				fmt.Fprintf(os.Stderr, "Error: invalid setting '%s', expected key=value.\n", arg)
+				return
+			}
+			if err := validateConfigValue(key, value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return
+			}
+			if err := internal.SetConfigValue(path, key, value); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				return
+			}
+		}
+		fmt.Printf("Updated %d setting(s) in %s.\n", len(args), path)
+	},
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var keys []string
+		for _, key := range configKeys() {
+			keys = append(keys, key+"=")
+		}
+		return keys, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	},
+}
+
+// configEditCmd represents the config edit command
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open the config file in an editor",
+	Long: `This command opens the config file of the active profile in $VISUAL or $EDITOR,
+creating it with the documented defaults if it does not exist. The file is
+checked once the editor exits.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running config edit command...")
+
+		path, err := internal.ConfigPath()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating config directory: %v\n", err)
+				return
+			}
+			if err := os.WriteFile(path, []byte(internal.ConfigTemplate()), 0644); err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating config file: %v\n", err)
+				return
+			}
+		}
+
+		editor := os.Getenv("VISUAL")
+		if editor == "" {
+			editor = os.Getenv("EDITOR")
+		}
+		if editor == "" {
+			editor = "vi"
+		}
+		fields := strings.Fields(editor)
+		c := exec.Command(fields[0], append(fields[1:], path)...)
+		c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := c.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error running %s: %v\n", editor, err)
+			return
+		}
+		if err := internal.LoadConfig(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+	},
+}
+
+// configPathCmd represents the config path command
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the location of the config file",
+	Long: `This command prints the config file of the active profile. With --all, the
+state directory holding the tokens and the cache directory holding the logs are
+printed too.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := internal.ConfigPath()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+		if !configPathAll {
+			fmt.Println(path)
+			return
+		}
+		fmt.Printf("config: %s\n", path)
+		if dir, err := internal.SeedrDir(); err == nil {
+			fmt.Printf("state:  %s\n", dir)
+		}
+		if dir, err := internal.CacheDir(); err == nil {
+			fmt.Printf("cache:  %s\n", dir)
+		}
+	},
+}
+
+var configPathAll bool
+
+func init() {
+	RootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configGetCmd, configSetCmd, configEditCmd, configPathCmd)
+	addOutputFlag(configGetCmd, "text", "json")
+	configPathCmd.Flags().BoolVarP(&configPathAll, "all", "a", false, "Also print the state and cache directories")
+}
+
+// initConfig loads the config file and applies it to the flags of the command being run.
+// A broken config file is reported but does not stop the command, so that it can still be fixed
+// with "config edit".
+func initConfig() {
+	if err := internal.LoadConfig(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	target, _, err := RootCmd.Find(os.Args[1:])
+	if err != nil || target == nil {
+		return
+	}
+	applyConfigDefaults(target)
+}
+
+// applyConfigDefaults sets every flag of c that was not given on the command line from the
+// setting named "<command path>.<flag>", e.g. "wishlist.auto.interval". The --output flag also
+// falls back to the global output setting when the command supports that format.
+func applyConfigDefaults(c *cobra.Command) {
+	prefix := commandConfigKey(c)
+	if prefix == "" {
+		return
+	}
+	c.LocalFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Changed {
+			return
+		}
+		key := prefix + "." + f.Name
+		v, ok := internal.Conf.Lookup(key)
+		if !ok && f.Name == "output" && internal.Conf.Output != "" {
+			if formats := f.Annotations[outputFormatsAnnotation]; containsString(formats, internal.Conf.Output) {
+				v, ok = internal.ConfigValue{Values: []string{internal.Conf.Output}}, true
+			}
+		}
+		if !ok {
+			return
+		}
+		var err error
+		if slice, isSlice := f.Value.(pflag.SliceValue); isSlice {
+			err = slice.Replace(v.Values)
+		} else {
+			err = f.Value.Set(v.String())
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring %s from %s: %v\n", key, v.Source, err)
+		}
+	})
+}
+
+// commandConfigKey returns the config section of a command, e.g. "wishlist.auto", or "" for the root.
+func commandConfigKey(c *cobra.Command) string {
+	var names []string
+	for ; c != nil && c.HasParent(); c = c.Parent() {
+		names = append([]string{c.Name()}, names...)
+	}
+	return strings.Join(names, ".")
+}
+
+// configFlag returns the command flag a setting such as "add.jobs" provides the default for.
+func configFlag(key string) *pflag.Flag {
+	i := strings.LastIndex(key, ".")
+	if i < 0 {
+		return nil
+	}
+	c := RootCmd
+	for _, name := range strings.Split(key[:i], ".") {
+		var next *cobra.Command
+		for _, sub := range c.Commands() {
+			if sub.Name() == name {
+				next = sub
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		c = next
+	}
+	return c.LocalFlags().Lookup(key[i+1:])
+}
+
+// configValue returns the effective value of a setting and where it comes from.
+func configValue(key string) (string, string, bool) {
+	if v, ok := internal.Conf.Lookup(key); ok {
+		return v.String(), v.Source, true
+	}
+	if opt, ok := internal.FindConfigOption(key); ok {
+		return opt.Default, "default", true
+	}
+	if f := configFlag(key); f != nil {
+		return f.DefValue, "flag default", true
+	}
+	return "", "", false
+}
+
+// configKeys returns the typed settings followed by the command flag defaults set in the config files.
+func configKeys() []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, opt := range internal.ConfigOptions {
+		keys = append(keys, opt.Key)
+		seen[opt.Key] = true
+	}
+	var extra []string
+	for _, key := range internal.Conf.Keys() {
+		if !seen[key] {
+			extra = append(extra, key)
+		}
+	}
+	sort.Strings(extra)
+	return append(keys, extra...)
+}
+
+// validateConfigValue checks that a value suits the setting or flag it is for.
+func validateConfigValue(key, value string) error {
+	if opt, ok := internal.FindConfigOption(key); ok {
+		if err := internal.ValidateConfigOption(opt, value); err != nil {
+			return fmt.Errorf("invalid value '%s' for %s: %v", value, key, err)
+		}
+		return nil
+	}
+	if table, name, ok := strings.Cut(key, "."); ok && containsString(internal.ConfigTables, table) && !strings.Contains(name, ".") {
+		return validateTableValue(table, value)
+	}
+	f := configFlag(key)
+	if f == nil {
+		return fmt.Errorf("unknown setting '%s'; see `seedr config get` and `seedr config --help`", key)
+	}
+	var err error
+	switch f.Value.Type() {
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "int":
+		_, err = strconv.Atoi(value)
+	case "duration":
+		_, err = time.ParseDuration(value)
+	case "stringArray", "stringSlice":
+		err = fmt.Errorf("lists have to be set with `seedr config edit`")
+	}
+	if err == nil && f.Name == "output" {
+		if formats := f.Annotations[outputFormatsAnnotation]; !containsString(formats, value) {
+			err = fmt.Errorf("expected one of %s", strings.Join(formats, ", "))
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value '%s' for %s: %v", value, key, err)
+	}
+	return nil
+}
+
+// validateTableValue checks an entry of a user-keyed table such as [handlers].
+func validateTableValue(table, value string) error {
+	switch table {
+	case "handlers":
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("a handler needs a command, e.g. \"vlc {url}\"")
+		}
+	case "filetypes":
+		if _, err := internal.FindHandler(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// completeConfigKeys completes setting names, including the flag defaults of every command.
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	keys := configKeys()
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		if prefix := commandConfigKey(c); prefix != "" {
+			c.LocalFlags().VisitAll(func(f *pflag.Flag) {
+				keys = append(keys, prefix+"."+f.Name)
+			})
+		}
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(RootCmd)
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
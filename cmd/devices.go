@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"seedr/internal"
+	"seedr/pkg/seedr"
+
+	"github.com/spf13/cobra"
+)
+
+// devicesCmd represents the devices command
+var devicesCmd = &cobra.Command{
+	Use:     "devices",
+	Aliases: []string{"device"},
+	Short:   "List and revoke devices connected to the account",
+	Long: `Devices such as Kodi boxes, browsers and this CLI hold tokens that give them
+access to your Seedr.cc account. These commands show which devices are
+connected and revoke their access. Without a subcommand, devices are listed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		devicesListCmd.Run(cmd, args)
+	},
+}
+
+// devicesListCmd represents the devices list command
+var devicesListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List connected devices",
+	Long: `This command lists the devices connected to your account. Secrets are masked.
+The device this CLI is logged in as is marked with *.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running devices list command...")
+		ctx := context.Background()
+
+		devices, err := internal.Account.GetDevices(ctx)
+		if err != nil {
+			fmt.Printf("Error getting devices: %v\n", err)
+			return
+		}
+
+		masked := make([]seedr.Device, 0, len(devices))
+		for _, d := range devices {
+			masked = append(masked, d.Masked())
+		}
+
+		if outputFormat == "json" {
+			if err := printJSON(masked); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding devices: %v\n", err)
+			}
+			return
+		}
+		if len(devices) == 0 {
+			fmt.Println("No devices connected.")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "\tCLIENT ID\tNAME\tTK")
+		for i, d := range masked {
+			current := ""
+			if isCurrentDevice(devices[i]) {
+				current = "*"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", current, d.ClientID, d.ClientName, d.TK)
+		}
+		w.Flush()
+	},
+}
+
+// devicesRevokeCmd represents the devices revoke command
+var devicesRevokeCmd = &cobra.Command{
+	Use:   "revoke <client_id>...",
+	Short: "Revoke a device's access to the account",
+	Long: `This command revokes the access of the given devices, which will have to log
+in again. The devices are listed and must be confirmed, unless --yes is given.
+
+Examples:
+  seedr devices revoke seedr_xbmc
+  seedr devices revoke chrome_ext seedr_xbmc --yes`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running devices revoke command...")
+		ctx := context.Background()
+
+		if len(args) == 0 {
+			fmt.Println("Please specify the client IDs of the devices to revoke.")
+			cmd.Help()
+			return
+		}
+
+		devices, err := internal.Account.GetDevices(ctx)
+		if err != nil {
+			fmt.Printf("Error getting devices: %v\n", err)
+			return
+		}
+		byClientID := make(map[string]seedr.Device, len(devices))
+		for _, d := range devices {
+			byClientID[d.ClientID] = d
+		}
+
+		var targets []seedr.Device
+		for _, id := range args {
+			d, ok := byClientID[id]
+			if !ok {
+				fmt.Printf("Error: no connected device with client ID '%s'.\n", id)
+				return
+			}
+			targets = append(targets, d)
+		}
+
+		for _, d := range targets {
+			fmt.Printf("  %s (%s)\n", d.ClientID, d.ClientName)
+			if isCurrentDevice(d) {
+				fmt.Println("    Warning: this is the device this CLI is logged in as; you will have to log in again.")
+			}
+		}
+		if !revokeYes {
+			if !stdinIsTerminal() {
+				fmt.Println("Refusing to revoke without confirmation; pass --yes to revoke non-interactively.")
+				return
+			}
+			if !confirm(fmt.Sprintf("Revoke %d device(s)?", len(targets))) {
+				fmt.Println("Nothing revoked.")
+				return
+			}
+		}
+
+		for _, d := range targets {
+			if _, err := internal.Account.RevokeDevice(ctx, d.ClientID); err != nil {
+				fmt.Printf("Error revoking '%s': %v\n", d.ClientID, err)
+				continue
+			}
+			fmt.Printf("Revoked '%s'.\n", d.ClientID)
+		}
+	},
+	ValidArgsFunction: completeDeviceIDs,
+}
+
+var revokeYes bool
+
+func init() {
+	RootCmd.AddCommand(devicesCmd)
+	devicesCmd.AddCommand(devicesListCmd, devicesRevokeCmd)
+	addOutputFlag(devicesListCmd, "text", "json")
+	devicesRevokeCmd.Flags().BoolVarP(&revokeYes, "yes", "y", false, "Revoke without asking for confirmation")
+}
+
+// isCurrentDevice reports whether d is the device whose token this CLI is using.
+func isCurrentDevice(d seedr.Device) bool {
+	if internal.Account == nil || d.DeviceCode == "" {
+		return false
+	}
+	code := internal.Account.Token().GetDeviceCode()
+	return code != nil && *code == d.DeviceCode
+}
+
+// completeDeviceIDs completes client IDs of connected devices, showing their names as descriptions.
+func completeDeviceIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	devices, err := internal.Account.GetDevices(context.Background())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var ids []string
+	for _, d := range devices {
+		ids = append(ids, fmt.Sprintf("%s\t%s", d.ClientID, d.ClientName))
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,359 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"seedr/internal"
+	"seedr/pkg/seedr"
+
+	"github.com/spf13/cobra"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export your Seedr library for other programs",
+	Long:  `Commands for exporting your Seedr files in formats other programs understand.`,
+}
+
+// exportStrmCmd represents the export strm command
+var exportStrmCmd = &cobra.Command{
+	Use:   "strm <remote-folder> <local-dir>",
+	Short: "Export videos as .strm files for Kodi, Jellyfin or Plex",
+	Long: `This command creates a .strm file for every video below a remote folder, so
+media centers such as Kodi and Jellyfin can add them to their library and
+stream them without downloading. Each .strm file holds a stable link to the
+video on a "seedr serve redirect" server at --base-url, which must be running
+when the videos are played. The links carry the token set as
+serve.redirect.token, if any.
+
+With --naming media (the default), episodes are named "Show/Season 01/Show
+S01E02.strm" and movies "Title (Year)/Title (Year).strm", as media servers
+expect; other videos, and all of them with --naming mirror, keep their path
+below the remote folder. When several videos get the same name, such as two
+versions of an episode, each gets its Seedr file ID appended: "Show
+S01E02 - 123.strm".
+
+Runs are incremental: only new or changed files are written, and files whose
+video was removed from Seedr are deleted unless --prune=false is given. Only
+files created by this command are ever overwritten or deleted; they are
+recorded in ` + strmManifestName + ` in the local directory. Other files in the
+way are reported as conflicts and left alone.
+
+Examples:
+  seedr export strm /Series ~/media/series
+  seedr export strm /Movies ~/media/movies --base-url http://nas:8080
+  seedr export strm / ~/media/seedr --naming mirror --dry-run`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running export strm command...")
+		ctx := context.Background()
+
+		if len(args) != 2 {
+			fmt.Println("Please specify the remote folder and the local directory.")
+			cmd.Help()
+			return
+		}
+		dir := filepath.Clean(args[1])
+		if strmNaming != "media" && strmNaming != "mirror" {
+			fmt.Printf("Error: invalid --naming '%s', expected media or mirror.\n", strmNaming)
+			return
+		}
+		if !strings.HasPrefix(strmBaseURL, "http://") && !strings.HasPrefix(strmBaseURL, "https://") {
+			fmt.Printf("Error: --base-url must be an http:// or https:// URL, not '%s'.\n", strmBaseURL)
+			return
+		}
+
+		folder, err := lookupObject(ctx, args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if !folder.isDir {
+			fmt.Printf("Error: '%s' is not a folder.\n", folder.path)
+			return
+		}
+
+		files, err := strmFiles(ctx, folder)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if err := syncStrmFiles(dir, files); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	},
+	ValidArgsFunction: CompleteSeedrObjectPrompt,
+}
+
+var (
+	strmBaseURL string
+	strmNaming  string
+	strmPrune   bool
+	strmDryRun  bool
+)
+
+func init() {
+	RootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportStrmCmd)
+	exportStrmCmd.Flags().StringVar(&strmBaseURL, "base-url", "http://127.0.0.1:8080", "URL of the \"seedr serve redirect\" server, as reachable from the media center")
+	exportStrmCmd.Flags().StringVar(&strmNaming, "naming", "media", "How to name the files (media|mirror)")
+	exportStrmCmd.Flags().BoolVar(&strmPrune, "prune", true, "Delete .strm files whose video was removed from Seedr")
+	exportStrmCmd.Flags().BoolVar(&strmDryRun, "dry-run", false, "Show what would change without writing anything")
+}
+
+// strmManifestName is the file recording the .strm files created in a directory.
+const strmManifestName = ".seedr-strm.json"
+
+// strmManifest records the .strm files created by the last run, by their path relative to the
+// local directory, with the link each holds.
+type strmManifest struct {
+	Files map[string]string `json:"files"`
+}
+
+var (
+	episodePattern = regexp.MustCompile(`(?i)^(.*?)[ ._-]*\bS(\d{1,2})[ ._-]?E(\d{1,3})\b`)
+	yearPattern    = regexp.MustCompile(`(?:19|20)\d{2}`)
+)
+
+// strmFiles walks the remote folder and returns the contents of every .strm file to create, by
+// local path relative to the target directory.
+func strmFiles(ctx context.Context, folder SeedrObject) (map[string]string, error) {
+	var videos []strmVideo
+	err := internal.Account.Walk(ctx, folder.id, func(p string, dir *seedr.Folder, file *seedr.File) error {
+		if file != nil && (file.PlayVideo || internal.FileType(file.Name) == "video") {
+			videos = append(videos, strmVideo{path: p, id: fmt.Sprintf("%d", file.FolderFileID)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing '%s': %w", folder.path, err)
+	}
+	return strmLinks(videos, configuredRedirectToken()), nil
+}
+
+// strmVideo is a video to export, by its path below the remote folder and its file ID.
+type strmVideo struct {
+	path string
+	id   string
+}
+
+// strmLinks names a .strm file for every video and returns their contents by name. The links
+// carry token, if it is set.
+func strmLinks(videos []strmVideo, token string) map[string]string {
+	names := make([]string, len(videos))
+	count := make(map[string]int)
+	for i, v := range videos {
+		if strmNaming == "media" {
+			names[i] = mediaServerName(v.path)
+		} else {
+			names[i] = strings.TrimSuffix(v.path, path.Ext(v.path))
+		}
+		count[names[i]]++
+	}
+
+	files := make(map[string]string, len(videos))
+	for i, v := range videos {
+		name := names[i]
+		if count[name] > 1 {
+			// Versions of the same episode or movie all get their ID, so a new upload doesn't
+			// rename the ones already exported
+			name += " - " + v.id
+		}
+		files[name+".strm"] = internal.RedirectURL(strmBaseURL, v.id, token) + "\n"
+	}
+	return files
+}
+
+// mediaServerName returns the name, without extension, that Kodi, Jellyfin and Plex expect for a
+// video: "Show/Season 01/Show S01E02" for episodes and "Title (Year)/Title (Year)" for movies.
+// Other videos keep their path.
+func mediaServerName(p string) string {
+	base := strings.TrimSuffix(path.Base(p), path.Ext(p))
+	// Underscores are word characters to the pattern's \b, so "show_s01e02_720p" needs them as spaces
+	if m := episodePattern.FindStringSubmatch(strings.ReplaceAll(base, "_", " ")); m != nil {
+		show := cleanMediaTitle(m[1])
+		if show == "" {
+			// Named like "S01E02.mkv"; the show is the folder it is in, skipping season folders
+			for dir := path.Dir(p); dir != "." && dir != "/"; dir = path.Dir(dir) {
+				if name := cleanMediaTitle(path.Base(dir)); !strings.HasPrefix(strings.ToLower(name), "season") {
+					show = episodePattern.ReplaceAllString(name, "$1")
+					break
+				}
+			}
+		}
+		if show != "" {
+			season, _ := strconv.Atoi(m[2])
+			episode, _ := strconv.Atoi(m[3])
+			return fmt.Sprintf("%s/Season %02d/%s S%02dE%02d", show, season, show, season, episode)
+		}
+	}
+	// The title ends at the last year standing on its own, e.g. "Blade.Runner.2049.2017.1080p"
+	years := yearPattern.FindAllStringIndex(base, -1)
+	for i := len(years) - 1; i >= 0; i-- {
+		start, end := years[i][0], years[i][1]
+		if start == 0 || !strings.ContainsRune(" ._([", rune(base[start-1])) || (end < len(base) && !strings.ContainsRune(" ._)]", rune(base[end]))) {
+			continue
+		}
+		if title := cleanMediaTitle(base[:start]); title != "" {
+			name := fmt.Sprintf("%s (%s)", title, base[start:end])
+			return name + "/" + name
+		}
+	}
+	return strings.TrimSuffix(p, path.Ext(p))
+}
+
+// cleanMediaTitle turns a release name such as "The.Show_Name " into "The Show Name", dropping
+// characters that are not allowed in file names.
+func cleanMediaTitle(s string) string {
+	s = strings.NewReplacer(".", " ", "_", " ").Replace(s)
+	s = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`<>:"/\|?*`, r) {
+			return -1
+		}
+		return r
+	}, s)
+	return strings.Join(strings.Fields(strings.Trim(s, " -([")), " ")
+}
+
+// syncStrmFiles makes dir hold exactly the given .strm files: new and changed ones are written and,
+// with --prune, those recorded by an earlier run but no longer wanted are deleted.
+func syncStrmFiles(dir string, files map[string]string) error {
+	manifestPath := filepath.Join(dir, strmManifestName)
+	var old strmManifest
+	if data, err := os.ReadFile(manifestPath); err == nil {
+		if err := json.Unmarshal(data, &old); err != nil {
+			return fmt.Errorf("invalid %s: %w", manifestPath, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	manifest := strmManifest{Files: make(map[string]string, len(files))}
+	var added, updated, unchanged, removed, conflicts, failed int
+	for _, name := range names {
+		target, err := strmTarget(dir, name)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			failed++
+			continue
+		}
+		existing, err := os.ReadFile(target)
+		if err == nil && string(existing) == files[name] {
+			unchanged++
+			manifest.Files[name] = files[name]
+			continue
+		}
+		exists := err == nil || !os.IsNotExist(err)
+		if _, ours := old.Files[name]; exists && !ours {
+			fmt.Printf("Conflict: %s already exists and was not created by this command; leaving it alone\n", name)
+			conflicts++
+			continue
+		}
+		if exists {
+			fmt.Printf("Updating %s\n", name)
+		} else {
+			fmt.Printf("Adding %s\n", name)
+		}
+		if !strmDryRun {
+			err := os.MkdirAll(filepath.Dir(target), 0755)
+			if err == nil {
+				err = os.WriteFile(target, []byte(files[name]), 0644)
+			}
+			if err != nil {
+				fmt.Printf("Error writing %s: %v\n", target, err)
+				failed++
+				continue
+			}
+		}
+		manifest.Files[name] = files[name]
+		if exists {
+			updated++
+		} else {
+			added++
+		}
+	}
+
+	for name, content := range old.Files {
+		if _, wanted := files[name]; wanted {
+			continue
+		}
+		target, err := strmTarget(dir, name)
+		if err != nil {
+			fmt.Printf("Error: %v; dropping it from %s\n", err, strmManifestName)
+			continue
+		}
+		if !strmPrune {
+			manifest.Files[name] = content // Still ours, to prune on a later run
+			continue
+		}
+		fmt.Printf("Removing %s\n", name)
+		if !strmDryRun {
+			if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+				fmt.Printf("Error removing %s: %v\n", target, err)
+				manifest.Files[name] = content
+				failed++
+				continue
+			}
+			removeEmptyParents(dir, filepath.Dir(target))
+		}
+		removed++
+	}
+
+	if strmDryRun {
+		fmt.Printf("Would add %d, update %d and remove %d .strm files; %d unchanged, %d conflicts.\n", added, updated, removed, unchanged, conflicts)
+		return nil
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(manifestPath, data, 0644); err != nil {
+		return fmt.Errorf("error saving %s: %w", manifestPath, err)
+	}
+	fmt.Printf("Added %d, updated %d and removed %d .strm files; %d unchanged, %d conflicts.\n", added, updated, removed, unchanged, conflicts)
+	switch {
+	case failed > 0:
+		return fmt.Errorf("%d files could not be written or removed", failed)
+	case conflicts > 0:
+		return fmt.Errorf("%d files were skipped because a file not created by this command is in the way", conflicts)
+	}
+	return nil
+}
+
+// strmTarget returns the local path of a .strm file, by its name relative to dir. Names that would
+// end up outside dir, such as "../x.strm", are rejected.
+func strmTarget(dir, name string) (string, error) {
+	local := filepath.FromSlash(name)
+	if !filepath.IsLocal(local) {
+		return "", fmt.Errorf("'%s' is not a path inside %s", name, dir)
+	}
+	return filepath.Join(dir, local), nil
+}
+
+// removeEmptyParents removes dir and its parents up to, but not including, root while they are empty.
+func removeEmptyParents(root, dir string) {
+	for dir != root && strings.HasPrefix(dir, root) {
+		if os.Remove(dir) != nil {
+			return // Not empty
+		}
+		dir = filepath.Dir(dir)
+	}
+}
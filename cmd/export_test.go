@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMediaServerName(t *testing.T) {
+	tests := []struct {
+		path, want string
+	}{
+		// Episodes
+		{"The.Show.S01E02.1080p.WEB.mkv", "The Show/Season 01/The Show S01E02"},
+		{"Shows/the_show_s1e5_720p.mkv", "the show/Season 01/the show S01E05"},
+		{"The Show - S02 E103.mp4", "The Show/Season 02/The Show S02E103"},
+		{"Show: Name S01E02.mkv", "Show Name/Season 01/Show Name S01E02"},
+		{"The Show/Season 1/S01E02.mkv", "The Show/Season 01/The Show S01E02"},
+		{"The.Show/Season 01/S01E02.mkv", "The Show/Season 01/The Show S01E02"},
+		{"Show (2019)/season 02/s2e10.mkv", "Show (2019)/Season 02/Show (2019) S02E10"},
+		// Movies
+		{"Heat.1995.1080p.BluRay.mkv", "Heat (1995)/Heat (1995)"},
+		{"Movies/Heat (1995) [1080p].mkv", "Heat (1995)/Heat (1995)"},
+		{"Blade.Runner.2049.2017.1080p.mkv", "Blade Runner 2049 (2017)/Blade Runner 2049 (2017)"},
+		{"2001.A.Space.Odyssey.1968.mkv", "2001 A Space Odyssey (1968)/2001 A Space Odyssey (1968)"},
+		{"The_Matrix_[1999].mp4", "The Matrix (1999)/The Matrix (1999)"},
+		// Neither; the path is kept
+		{"1917.mkv", "1917"},
+		{"Clips/holiday20201.mp4", "Clips/holiday20201"},
+		{"Clips/Random Video.mp4", "Clips/Random Video"},
+		{"S01E02.mkv", "S01E02"},
+	}
+	for _, tt := range tests {
+		if got := mediaServerName(tt.path); got != tt.want {
+			t.Errorf("mediaServerName(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCleanMediaTitle(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"The.Show_Name ", "The Show Name"},
+		{"  -Movie- ", "Movie"},
+		{"Heat (", "Heat"},
+		{"What/If?: A|B*", "WhatIf AB"},
+		{"Mr. Robot", "Mr Robot"},
+		{"", ""},
+		{"._-", ""},
+	}
+	for _, tt := range tests {
+		if got := cleanMediaTitle(tt.in); got != tt.want {
+			t.Errorf("cleanMediaTitle(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// setStrmFlags sets the export strm flags for the test and restores them afterwards.
+func setStrmFlags(t *testing.T, naming string, prune, dryRun bool) {
+	t.Helper()
+	oldBase, oldNaming, oldPrune, oldDryRun := strmBaseURL, strmNaming, strmPrune, strmDryRun
+	t.Cleanup(func() { strmBaseURL, strmNaming, strmPrune, strmDryRun = oldBase, oldNaming, oldPrune, oldDryRun })
+	strmBaseURL, strmNaming, strmPrune, strmDryRun = "http://nas:8080/", naming, prune, dryRun
+}
+
+func TestStrmLinks(t *testing.T) {
+	setStrmFlags(t, "media", true, false)
+	videos := []strmVideo{
+		{"Show.S01E01.1080p.mkv", "11"},
+		{"Show.S01E01.720p.mkv", "12"},
+		{"Show.S01E02.mkv", "13"},
+		{"Clips/a.mp4", "14"},
+	}
+	want := map[string]string{
+		"Show/Season 01/Show S01E01 - 11.strm": "http://nas:8080/f/11?token=secret\n",
+		"Show/Season 01/Show S01E01 - 12.strm": "http://nas:8080/f/12?token=secret\n",
+		"Show/Season 01/Show S01E02.strm":      "http://nas:8080/f/13?token=secret\n",
+		"Clips/a.strm":                         "http://nas:8080/f/14?token=secret\n",
+	}
+	if got := strmLinks(videos, "secret"); !reflect.DeepEqual(got, want) {
+		t.Errorf("strmLinks = %q, want %q", got, want)
+	}
+
+	// A new version that sorts first leaves the names of the others as they were
+	videos = append([]strmVideo{{"Show.S01E01.2160p.mkv", "10"}}, videos...)
+	want["Show/Season 01/Show S01E01 - 10.strm"] = "http://nas:8080/f/10?token=secret\n"
+	if got := strmLinks(videos, "secret"); !reflect.DeepEqual(got, want) {
+		t.Errorf("strmLinks with a new version = %q, want %q", got, want)
+	}
+
+	strmNaming = "mirror"
+	want = map[string]string{
+		"Show.S01E01.2160p.strm": "http://nas:8080/f/10\n",
+		"Show.S01E01.1080p.strm": "http://nas:8080/f/11\n",
+		"Show.S01E01.720p.strm":  "http://nas:8080/f/12\n",
+		"Show.S01E02.strm":       "http://nas:8080/f/13\n",
+		"Clips/a.strm":           "http://nas:8080/f/14\n",
+	}
+	if got := strmLinks(videos, ""); !reflect.DeepEqual(got, want) {
+		t.Errorf("strmLinks with --naming mirror = %q, want %q", got, want)
+	}
+}
+
+// readStrmDir returns the files below dir by slash-separated relative path, and the manifest.
+func readStrmDir(t *testing.T, dir string) (map[string]string, map[string]string) {
+	t.Helper()
+	files := make(map[string]string)
+	err := filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, p)
+		data, err := os.ReadFile(p)
+		files[filepath.ToSlash(rel)] = string(data)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest strmManifest
+	if data, ok := files[strmManifestName]; ok {
+		if err := json.Unmarshal([]byte(data), &manifest); err != nil {
+			t.Fatalf("invalid manifest: %v", err)
+		}
+		delete(files, strmManifestName)
+	}
+	return files, manifest.Files
+}
+
+func TestSyncStrmFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "media")
+
+	steps := []struct {
+		name          string
+		prune, dryRun bool
+		before        map[string]string // Files written by hand before the run
+		files         map[string]string
+		wantErr       bool
+		wantFiles     map[string]string
+		wantManifest  map[string]string
+	}{
+		{
+			name:         "add",
+			prune:        true,
+			files:        map[string]string{"Show/Season 01/Show S01E01.strm": "a\n", "Movie (2000)/Movie (2000).strm": "b\n"},
+			wantFiles:    map[string]string{"Show/Season 01/Show S01E01.strm": "a\n", "Movie (2000)/Movie (2000).strm": "b\n"},
+			wantManifest: map[string]string{"Show/Season 01/Show S01E01.strm": "a\n", "Movie (2000)/Movie (2000).strm": "b\n"},
+		},
+		{
+			name:         "dry run",
+			prune:        true,
+			dryRun:       true,
+			files:        map[string]string{"Other.strm": "c\n"},
+			wantFiles:    map[string]string{"Show/Season 01/Show S01E01.strm": "a\n", "Movie (2000)/Movie (2000).strm": "b\n"},
+			wantManifest: map[string]string{"Show/Season 01/Show S01E01.strm": "a\n", "Movie (2000)/Movie (2000).strm": "b\n"},
+		},
+		{
+			name:         "update and keep without --prune",
+			prune:        false,
+			files:        map[string]string{"Show/Season 01/Show S01E01.strm": "a2\n"},
+			wantFiles:    map[string]string{"Show/Season 01/Show S01E01.strm": "a2\n", "Movie (2000)/Movie (2000).strm": "b\n"},
+			wantManifest: map[string]string{"Show/Season 01/Show S01E01.strm": "a2\n", "Movie (2000)/Movie (2000).strm": "b\n"},
+		},
+		{
+			name:         "prune",
+			prune:        true,
+			files:        map[string]string{"Show/Season 01/Show S01E01.strm": "a2\n"},
+			wantFiles:    map[string]string{"Show/Season 01/Show S01E01.strm": "a2\n"},
+			wantManifest: map[string]string{"Show/Season 01/Show S01E01.strm": "a2\n"},
+		},
+		{
+			name:         "conflict",
+			prune:        true,
+			before:       map[string]string{"Mine.strm": "mine\n", "Show/Season 01/notes.txt": "keep\n"},
+			files:        map[string]string{"Show/Season 01/Show S01E01.strm": "a2\n", "Mine.strm": "d\n"},
+			wantErr:      true,
+			wantFiles:    map[string]string{"Show/Season 01/Show S01E01.strm": "a2\n", "Mine.strm": "mine\n", "Show/Season 01/notes.txt": "keep\n"},
+			wantManifest: map[string]string{"Show/Season 01/Show S01E01.strm": "a2\n"},
+		},
+		{
+			name:         "prune keeps other files",
+			prune:        true,
+			files:        map[string]string{"Mine.strm": "mine\n"},
+			wantFiles:    map[string]string{"Mine.strm": "mine\n", "Show/Season 01/notes.txt": "keep\n"},
+			wantManifest: map[string]string{"Mine.strm": "mine\n"},
+		},
+		{
+			name:         "unsafe names",
+			prune:        true,
+			files:        map[string]string{"Mine.strm": "mine\n", "../escape.strm": "x\n"},
+			wantErr:      true,
+			wantFiles:    map[string]string{"Mine.strm": "mine\n", "Show/Season 01/notes.txt": "keep\n"},
+			wantManifest: map[string]string{"Mine.strm": "mine\n"},
+		},
+	}
+	for _, step := range steps {
+		setStrmFlags(t, "media", step.prune, step.dryRun)
+		for name, content := range step.before {
+			target := filepath.Join(dir, filepath.FromSlash(name))
+			os.MkdirAll(filepath.Dir(target), 0755)
+			if err := os.WriteFile(target, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		err := syncStrmFiles(dir, step.files)
+		if (err != nil) != step.wantErr {
+			t.Errorf("%s: error = %v, want an error: %v", step.name, err, step.wantErr)
+		}
+		files, manifest := readStrmDir(t, dir)
+		if !reflect.DeepEqual(files, step.wantFiles) {
+			t.Errorf("%s: files = %q, want %q", step.name, files, step.wantFiles)
+		}
+		if !reflect.DeepEqual(manifest, step.wantManifest) {
+			t.Errorf("%s: manifest = %q, want %q", step.name, manifest, step.wantManifest)
+		}
+	}
+
+	// Pruning removed the folders it emptied
+	if _, err := os.Stat(filepath.Join(dir, "Movie (2000)")); !os.IsNotExist(err) {
+		t.Errorf("empty folder of a pruned file left behind: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(dir), "escape.strm")); !os.IsNotExist(err) {
+		t.Errorf("file written outside the directory: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "Mine.strm")); !strings.HasPrefix(string(data), "mine") {
+		t.Errorf("Mine.strm = %q", data)
+	}
+}
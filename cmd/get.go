@@ -9,40 +9,36 @@ import (
 
 // getCmd represents the get command
 var getCmd = &cobra.Command{
-	Use:     "get",
+	Use:     "get <path>...",
 	Aliases: []string{"g"},
 	Short:   "Get download URL of files/folders",
-	Long:    `This command fetches and prints the download URL for a specified file or folder from your Seedr.cc account.`,
+	Long: `This command fetches and prints the download URL for the specified files or folders from your Seedr.cc account.
+Items can be given by name or as absolute paths. Pass "-" to read paths from stdin, one per line.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		internal.Log.Debug("Running get command...\n")
+		ctx := context.Background()
 
 		if len(args) == 0 {
 			fmt.Println("Please specify the name of the file or folder you want to get the download URL for.")
 			cmd.Help()
 			return
 		}
-		if len(args) > 1 {
-			fmt.Println("Please specify only one item name at a time.")
-			return
-		}
-
-		itemName := args[0]
-		internal.Log.Debug("Trying to Fetch ID for %s", itemName)
-		
-		// Ensure cache is populated
-		_, err := FetchObjectDetails()
+		args, err := readArgs(args)
 		if err != nil {
-			fmt.Printf("Error fetching Seedr objects for lookup: %v\n", err)
+			fmt.Printf("Error: %v\n", err)
 			return
 		}
 
-		obj, ok := allSeedrObjects[itemName]
-		if !ok {
-			fmt.Printf("Error: Item '%s' not found in your Seedr account. Please check the name and try again.\n", itemName)
-			return
+		for _, itemName := range args {
+			internal.Log.Debug("Trying to Fetch ID for %s", itemName)
+			obj, err := lookupObject(ctx, itemName)
+			if err != nil {
+				fmt.Printf("Error: %v. Please check the name and try again.\n", err)
+				continue
+			}
+			internal.Log.Debug("Trying to Fetch ID for %s - ID : %s", itemName, obj.id)
+			getDownloadURL(obj.isDir, obj.id)
 		}
-		internal.Log.Debug("Trying to Fetch ID for %s - ID : %s", itemName, obj.id)
-		getDownloadURL(obj.isDir, obj.id)
 	},
 	ValidArgsFunction: completegetPrompt,
 }
@@ -77,5 +73,5 @@ func getDownloadURL(isDirectory bool, id string) {
 }
 
 func completegetPrompt(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	return CompleteSeedrObjectPrompt(cmd, args, toComplete)
+	return CompleteSeedrObjectPrompt(cmd, nil, toComplete)
 }
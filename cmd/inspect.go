@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"seedr/internal"
+	"seedr/pkg/seedr"
+
+	"github.com/spf13/cobra"
+)
+
+// inspectCmd represents the inspect command
+var inspectCmd = &cobra.Command{
+	Use:   "inspect <file.torrent|magnet>",
+	Short: "Show the contents of a local .torrent file or magnet link",
+	Long: `This command parses a local .torrent file or a magnet link without contacting
+Seedr and prints its name, infohashes (v1 and v2), trackers and file list.
+
+Magnet links only carry the infohash, name and trackers, so their file list is
+unknown until the torrent's metadata has been fetched.
+
+Examples:
+  seedr inspect ubuntu.iso.torrent
+  seedr inspect "magnet:?xt=urn:btih:..." --output json`,
+	// Inspecting works offline, so skip the root hook that logs in to Seedr.
+	PersistentPreRunE: skipLogin,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running inspect command...")
+
+		if len(args) != 1 {
+			fmt.Println("Please specify a single .torrent file or magnet link.")
+			cmd.Help()
+			return
+		}
+
+		info, err := inspectSource(args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if outputFormat == "json" {
+			if err := printJSON(info); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding torrent info: %v\n", err)
+			}
+			return
+		}
+		printTorrentInfo(info)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(inspectCmd)
+	addOutputFlag(inspectCmd, "text", "json")
+}
+
+// inspectSource parses a magnet link or reads and parses a local .torrent file.
+func inspectSource(source string) (*seedr.TorrentInfo, error) {
+	if magnetPattern.MatchString(source) {
+		return seedr.ParseMagnet(source)
+	}
+	data, err := os.ReadFile(source)
+	if err != nil {
+		return nil, fmt.Errorf("error reading torrent file '%s': %w", source, err)
+	}
+	return seedr.ParseTorrentFile(data)
+}
+
+// printTorrentInfo prints parsed torrent metadata in a human-readable layout.
+func printTorrentInfo(info *seedr.TorrentInfo) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Name:\t%s\n", info.Name)
+	if info.InfoHashV1 != "" {
+		fmt.Fprintf(w, "Infohash (v1):\t%s\n", info.InfoHashV1)
+	}
+	if info.InfoHashV2 != "" {
+		fmt.Fprintf(w, "Infohash (v2):\t%s\n", info.InfoHashV2)
+	}
+	if info.TotalSize > 0 {
+		fmt.Fprintf(w, "Total size:\t%s (%d bytes)\n", internal.HumanReadableBytes(int(info.TotalSize)), info.TotalSize)
+	}
+	if info.PieceSize > 0 {
+		fmt.Fprintf(w, "Piece size:\t%s\n", internal.HumanReadableBytes(int(info.PieceSize)))
+	}
+	if info.Private {
+		fmt.Fprintf(w, "Private:\tyes\n")
+	}
+	if info.CreatedBy != "" {
+		fmt.Fprintf(w, "Created by:\t%s\n", info.CreatedBy)
+	}
+	if info.Comment != "" {
+		fmt.Fprintf(w, "Comment:\t%s\n", strings.TrimSpace(info.Comment))
+	}
+	w.Flush()
+
+	if len(info.Trackers) > 0 {
+		fmt.Printf("\nTrackers (%d):\n", len(info.Trackers))
+		for _, tr := range info.Trackers {
+			fmt.Printf("  %s\n", tr)
+		}
+	}
+
+	if len(info.Files) > 0 {
+		fmt.Printf("\nFiles (%d):\n", len(info.Files))
+		fw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+		for _, f := range info.Files {
+			fmt.Fprintf(fw, "  %s\t  %s\n", internal.HumanReadableBytes(int(f.Size)), f.Path)
+		}
+		fw.Flush()
+	}
+}
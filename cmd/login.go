@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
+
 	"seedr/internal"
+
 	"github.com/spf13/cobra"
 )
 
@@ -11,17 +15,68 @@ import (
 var loginCmd = &cobra.Command{
 	Use:   "login",
 	Short: "Log into Seedr",
-	Long:  `This command initiates the device authentication flow to log into Seedr and saves the token for future use.`,
+	Long: `This command logs into Seedr and saves the token for future use, replacing any
+saved token.
+
+By default the device flow is used: a code is printed to enter on the Seedr
+website, and the command waits until the device is authorized or the code
+expires. With --password, log in with your username and password instead. The
+password is prompted for without echo, or read from stdin when it is not a
+terminal.
+
+Examples:
+  seedr login
+  seedr login --password --username me@example.com
+  printf '%s\n' "$SEEDR_PASSWORD" | seedr login --password -u me@example.com`,
+	// Logging in must not trigger the automatic device flow in the root hook.
+	PersistentPreRunE: skipLogin,
 	Run: func(cmd *cobra.Command, args []string) {
 		internal.Log.Debug("Running login command...")
-		if err := internal.FetchSeedrAccessToken(); err != nil {
+		ctx := context.Background()
+
+		var err error
+		if loginWithPassword {
+			err = passwordLogin(ctx)
+		} else {
+			err = internal.LoginWithDeviceCode(ctx)
+		}
+		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error during login: %v\n", err)
 			return
 		}
-		fmt.Println("Login successful and token stored (or refreshed).")
+		fmt.Println("Login successful and token stored.")
 	},
 }
 
+var (
+	loginWithPassword bool
+	loginUsername     string
+)
+
 func init() {
 	RootCmd.AddCommand(loginCmd)
+	loginCmd.Flags().BoolVarP(&loginWithPassword, "password", "p", false, "Log in with a username and password instead of the device flow")
+	loginCmd.Flags().StringVarP(&loginUsername, "username", "u", "", "Username (email) for --password; prompted for if omitted")
+}
+
+// passwordLogin asks for any missing credentials and logs in with them.
+func passwordLogin(ctx context.Context) error {
+	username := loginUsername
+	if username == "" {
+		fmt.Fprint(os.Stderr, "Username: ")
+		line, err := stdinReader.ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("error reading username: %w", err)
+		}
+		username = strings.TrimSpace(line)
+	}
+	if username == "" {
+		return fmt.Errorf("a username is required")
+	}
+
+	password, err := readPassword("Password: ")
+	if err != nil {
+		return err
+	}
+	return internal.LoginWithPassword(ctx, username, password)
 }
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"seedr/internal"
+
+	"github.com/spf13/cobra"
+)
+
+// logoutCmd represents the logout command
+var logoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Log out of Seedr",
+	Long: `This command deletes the saved token. With --revoke, the device this CLI is
+logged in as is also revoked on Seedr, so a copy of the token stops working too.`,
+	PersistentPreRunE: skipLogin,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running logout command...")
+		ctx := context.Background()
+
+		if err := internal.LoadSavedAccount(); err != nil {
+			if errors.Is(err, internal.ErrNotLoggedIn) {
+				fmt.Println("Not logged in.")
+				return
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+
+		if logoutRevoke {
+			if err := revokeCurrentDevice(ctx); err != nil {
+				fmt.Fprintf(os.Stderr, "Error revoking this device: %v\n", err)
+				fmt.Fprintln(os.Stderr, "The token was kept; run logout without --revoke to delete it anyway.")
+				return
+			}
+		}
+
+		if err := internal.RemoveSavedToken(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+		fmt.Println("Logged out.")
+	},
+}
+
+// whoamiCmd represents the whoami command
+var whoamiCmd = &cobra.Command{
+	Use:               "whoami",
+	Short:             "Show the logged-in user and token",
+	Long:              `This command shows the username of the logged-in account and the saved token, with its secrets masked.`,
+	PersistentPreRunE: skipLogin,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running whoami command...")
+		ctx := context.Background()
+
+		if err := internal.LoadSavedAccount(); err != nil {
+			if errors.Is(err, internal.ErrNotLoggedIn) {
+				fmt.Println("Not logged in.")
+				return
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+
+		settings, err := internal.Account.GetSettings(ctx)
+		if err != nil {
+			fmt.Printf("Token: %s\n", internal.Account.Token().String())
+			fmt.Fprintf(os.Stderr, "Error getting account details: %v\n", err)
+			return
+		}
+		fmt.Printf("Username: %s\n", settings.Account.Username)
+		if settings.Account.Email != "" {
+			fmt.Printf("Email: %s\n", settings.Account.Email)
+		}
+		fmt.Printf("Token: %s\n", internal.Account.Token().String())
+	},
+}
+
+var logoutRevoke bool
+
+func init() {
+	RootCmd.AddCommand(logoutCmd, whoamiCmd)
+	logoutCmd.Flags().BoolVar(&logoutRevoke, "revoke", false, "Also revoke this device on Seedr")
+}
+
+// revokeCurrentDevice revokes the device whose token the CLI is using.
+func revokeCurrentDevice(ctx context.Context) error {
+	devices, err := internal.Account.GetDevices(ctx)
+	if err != nil {
+		return err
+	}
+	for _, d := range devices {
+		if isCurrentDevice(d) {
+			if _, err := internal.Account.RevokeDevice(ctx, d.ClientID); err != nil {
+				return err
+			}
+			fmt.Printf("Revoked device '%s'.\n", d.ClientID)
+			return nil
+		}
+	}
+	return fmt.Errorf("this session is not listed as a device (password logins are not devices)")
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"seedr/internal"
+	"seedr/pkg/seedr"
+
+	"github.com/spf13/cobra"
+)
+
+// mvCmd represents the mv command
+var mvCmd = &cobra.Command{
+	Use:   "mv <path> <new-name> | mv <path>... <dest-folder>/",
+	Short: "Rename a file or folder, or move items into another folder",
+	Long: `This command renames a single file or folder, or moves items into another folder.
+Items can be given as absolute paths (e.g. /Movies/x.mkv), shell-style patterns
+(quoted, e.g. '/Downloads/*.mkv') or by name.
+
+When the last argument contains a slash, or more than two arguments are given,
+the last argument is the destination folder and every other item is moved into it.
+Otherwise the item is renamed in place.
+
+Examples:
+  seedr mv /Movies/Some.Movie.1080p.mkv "Some Movie.mkv"
+  seedr mv /Show.S01E01.mkv /Show.S01E02.mkv "/TV/Show/Season 1/"
+  seedr mv '/Downloads/*.mkv' /Movies/`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running mv command...")
+		ctx := context.Background()
+
+		if len(args) < 2 {
+			fmt.Println("Please specify the item to rename and its new name, or the items to move and a destination folder.")
+			cmd.Help()
+			return
+		}
+
+		dest := args[len(args)-1]
+		if len(args) > 2 || strings.Contains(dest, "/") {
+			moveItems(ctx, args[:len(args)-1], dest)
+			return
+		}
+		renameItem(ctx, args[0], dest)
+	},
+	ValidArgsFunction: completemvPrompt,
+}
+
+func init() {
+	RootCmd.AddCommand(mvCmd)
+}
+
+func completemvPrompt(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return CompleteSeedrObjectPrompt(cmd, nil, toComplete)
+}
+
+// renameItem renames the item at src to newName within its current folder.
+func renameItem(ctx context.Context, src, newName string) {
+	if err := checkItemName(newName); err != nil {
+		fmt.Printf("Error: '%s' is not a valid name: %v.\n", newName, err)
+		return
+	}
+
+	obj, err := lookupObject(ctx, src)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if obj.name == newName {
+		fmt.Println("Nothing to rename.")
+		return
+	}
+
+	conflicts, err := findRenameConflicts(ctx, []renamePlan{{obj: obj, newName: newName}})
+	if err != nil {
+		fmt.Printf("Error checking for conflicts: %v\n", err)
+		return
+	}
+	if len(conflicts) > 0 {
+		fmt.Printf("Error: %s\n", conflicts[0])
+		return
+	}
+
+	if err := renameObject(ctx, obj, newName); err != nil {
+		fmt.Printf("Error renaming '%s': %v\n", obj.path, err)
+		return
+	}
+	fmt.Printf("Renamed '%s' -> '%s'.\n", obj.path, newName)
+}
+
+// moveItems moves every source item into the destination folder in a single request.
+func moveItems(ctx context.Context, srcs []string, dest string) {
+	destObj, err := resolvePath(ctx, dest)
+	if err != nil {
+		fmt.Printf("Error resolving destination: %v\n", err)
+		return
+	}
+	if !destObj.isDir {
+		fmt.Printf("Error: destination '%s' is not a folder.\n", destObj.path)
+		return
+	}
+
+	objects, err := expandArgs(ctx, srcs)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	existing, err := folderChildren(ctx, destObj)
+	if err != nil {
+		fmt.Printf("Error listing '%s': %v\n", destObj.path, err)
+		return
+	}
+	taken := make(map[string]bool)
+	for _, child := range existing {
+		taken[child.name] = true
+	}
+
+	var refs []seedr.ItemRef
+	for _, obj := range objects {
+		if obj.parentID == destObj.id {
+			fmt.Printf("Skipping '%s': already in '%s'.\n", obj.path, destObj.path)
+			continue
+		}
+		if obj.isDir && (destObj.path == obj.path || strings.HasPrefix(destObj.path, obj.path+"/")) {
+			fmt.Printf("Error: cannot move '%s' into itself.\n", obj.path)
+			return
+		}
+		if taken[obj.name] {
+			fmt.Printf("Error: '%s' already exists in '%s'.\n", obj.name, destObj.path)
+			return
+		}
+		taken[obj.name] = true
+		refs = append(refs, seedr.ItemRef{Type: obj.itemType, ID: obj.id})
+	}
+	if len(refs) == 0 {
+		fmt.Println("Nothing to move.")
+		return
+	}
+
+	if _, err := internal.Account.Move(ctx, refs, destObj.id); err != nil {
+		fmt.Printf("Error moving items to '%s': %v\n", destObj.path, err)
+		return
+	}
+	for _, obj := range objects {
+		invalidateFolder(obj.parentID)
+	}
+	invalidateFolder(destObj.id)
+	fmt.Printf("Moved %d item(s) to '%s'.\n", len(refs), destObj.path)
+}
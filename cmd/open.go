@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"seedr/internal"
+
+	"github.com/spf13/cobra"
+)
+
+// openCmd represents the open command
+var openCmd = &cobra.Command{
+	Use:   "open <path>...",
+	Short: "Open files with an external program",
+	Long: `This command opens files in an external program, e.g. a video player, by
+passing it the download URL. The program is chosen by the file's extension or
+type from [filetypes] in the config file, or given with --with as a handler name
+or as a command template. Use --list to show the available handlers; see
+"seedr config --help" for how to add your own.
+
+Videos resume from the position saved on Seedr when the handler passes {start}
+to the player, as the built-in ones do; use --from-start to start over.
+
+Examples:
+  seedr open /Movies/Big.Buck.Bunny.mkv
+  seedr open /Movies/Big.Buck.Bunny.mkv --with vlc
+  seedr open '/Music/*.flac' --with 'mpv --no-video {url}'
+  seedr recent -o paths | head -1 | seedr open -`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running open command...")
+		ctx := context.Background()
+
+		if openList {
+			printHandlers()
+			return
+		}
+		if len(args) == 0 {
+			fmt.Println("Please specify the files to open.")
+			cmd.Help()
+			return
+		}
+
+		args, err := readArgs(args)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		var handler internal.Handler
+		if openWith != "" {
+			if handler, err = internal.FindHandler(openWith); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+
+		objects, err := expandArgs(ctx, args)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		for _, obj := range objects {
+			if obj.itemType != "file" {
+				fmt.Printf("Skipping '%s': only files can be opened.\n", obj.path)
+				continue
+			}
+			if err := openObject(ctx, obj, handler); err != nil {
+				fmt.Printf("Error opening '%s': %v\n", obj.path, err)
+			}
+		}
+	},
+	ValidArgsFunction: CompleteSeedrObjectPrompt,
+}
+
+var (
+	openWith      string
+	openWait      bool
+	openList      bool
+	openFromStart bool
+)
+
+func init() {
+	RootCmd.AddCommand(openCmd)
+	openCmd.Flags().StringVarP(&openWith, "with", "w", "", "Handler name or command template to open the files with")
+	openCmd.Flags().BoolVar(&openWait, "wait", false, "Run the program in this terminal and wait for it to exit")
+	openCmd.Flags().BoolVarP(&openList, "list", "l", false, "List the available handlers")
+	openCmd.Flags().BoolVar(&openFromStart, "from-start", false, "Ignore the saved playback position of videos")
+	openCmd.RegisterFlagCompletionFunc("with", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var names []string
+		for _, h := range internal.Handlers() {
+			names = append(names, fmt.Sprintf("%s\t%s", h.Name, h.Template))
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// openObject fetches a file's download URL and runs the handler for it. A zero handler means the
+// one configured for the file's type.
+func openObject(ctx context.Context, obj SeedrObject, handler internal.Handler) error {
+	if handler.Template == "" {
+		var err error
+		if handler, err = internal.HandlerFor(obj.name); err != nil {
+			return err
+		}
+	}
+	fileResult, err := internal.Account.FetchFile(ctx, obj.id)
+	if err != nil {
+		return fmt.Errorf("error fetching download URL: %w", err)
+	}
+	vars := internal.HandlerVars{URL: fileResult.URL, Name: obj.name, Path: obj.path, ID: obj.id}
+	if !openFromStart {
+		vars.Start = obj.progress
+	}
+
+	if vars.Start > 0 {
+		fmt.Printf("Opening '%s' with %s at %s...\n", obj.path, handler.Name, internal.FormatPosition(vars.Start))
+	} else {
+		fmt.Printf("Opening '%s' with %s...\n", obj.path, handler.Name)
+	}
+	if !openWait {
+		return handler.Start(vars)
+	}
+	c, err := handler.Command(vars)
+	if err != nil {
+		return err
+	}
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return c.Run()
+}
+
+// printHandlers lists the handlers and the extensions and file types they are used for.
+func printHandlers() {
+	uses := make(map[string][]string)
+	for key, name := range internal.FileTypeHandlers() {
+		if _, isType := fileTypeNames[key]; !isType {
+			key = "." + key
+		}
+		uses[name] = append(uses[name], key)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "HANDLER\tCOMMAND\tUSED FOR")
+	for _, h := range internal.Handlers() {
+		used := "-"
+		if len(uses[h.Name]) > 0 {
+			sort.Strings(uses[h.Name])
+			used = strings.Join(uses[h.Name], ", ")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", h.Name, h.Template, used)
+	}
+	w.Flush()
+}
+
+// fileTypeNames are the [filetypes] keys that are not extensions.
+var fileTypeNames = map[string]bool{"video": true, "audio": true, "image": true, "document": true, "default": true}
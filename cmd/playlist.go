@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"seedr/internal"
+	"seedr/pkg/seedr"
+
+	"github.com/spf13/cobra"
+)
+
+// playlistCmd represents the playlist command
+var playlistCmd = &cobra.Command{
+	Use:   "playlist <folder>",
+	Short: "Make a playlist of the videos and songs in a folder",
+	Long: `This command makes an M3U or XSPF playlist of the playable files in a folder,
+e.g. a season or an album, in natural order ("Episode 2" before "Episode 10").
+The playlist is printed, written to a file with --write, or handed to the
+configured player with --play.
+
+The download URLs in the playlist are signed and expire after a while. Run the
+command again to refresh them, or use --redirect to point the playlist at
+"seedr serve redirect", whose links don't expire. The links carry the token
+set as serve.redirect.token, if any.
+
+Examples:
+  seedr playlist "/Series/Show S01" --play
+  seedr playlist /Music --recursive --write music.m3u
+  seedr playlist /Music/Album --format xspf > album.xspf
+  seedr playlist /Series --recursive --redirect http://127.0.0.1:8080 -w series.m3u`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running playlist command...")
+		ctx := context.Background()
+
+		if len(args) != 1 {
+			fmt.Println("Please specify a single folder.")
+			cmd.Help()
+			return
+		}
+		format := playlistFormat
+		if !cmd.Flags().Changed("format") && strings.EqualFold(filepath.Ext(playlistWrite), ".xspf") {
+			format = "xspf"
+		}
+		if !containsString(internal.PlaylistFormats, format) {
+			fmt.Printf("Error: invalid --format '%s', expected %s.\n", format, strings.Join(internal.PlaylistFormats, " or "))
+			return
+		}
+		if playlistPlay && playlistWrite != "" {
+			fmt.Println("Error: --play and --write cannot be combined.")
+			return
+		}
+
+		folder, err := lookupObject(ctx, args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if !folder.isDir {
+			fmt.Printf("Error: '%s' is not a folder.\n", folder.path)
+			return
+		}
+
+		items, err := folderPlaylist(ctx, folder)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if len(items) == 0 {
+			fmt.Printf("No videos or songs in '%s'.\n", folder.path)
+			return
+		}
+
+		switch {
+		case playlistPlay:
+			err = playPlaylist(folder.name, format, items)
+		case playlistWrite != "":
+			err = writePlaylistFile(playlistWrite, folder.name, format, items)
+			if err == nil {
+				fmt.Fprintf(os.Stderr, "Wrote %d entries to %s.\n", len(items), playlistWrite)
+			}
+		default:
+			err = internal.WritePlaylist(os.Stdout, format, folder.name, items)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+		if !playlistPlay && playlistRedirect == "" {
+			fmt.Fprintln(os.Stderr, "Note: the links in this playlist are signed and expire; run the command again when they stop working.")
+		}
+	},
+	ValidArgsFunction: CompleteSeedrObjectPrompt,
+}
+
+var (
+	playlistFormat    string
+	playlistRecursive bool
+	playlistWrite     string
+	playlistPlay      bool
+	playlistRedirect  string
+)
+
+func init() {
+	RootCmd.AddCommand(playlistCmd)
+	playlistCmd.Flags().StringVarP(&playlistFormat, "format", "f", "m3u", "Playlist format ("+strings.Join(internal.PlaylistFormats, "|")+")")
+	playlistCmd.Flags().BoolVarP(&playlistRecursive, "recursive", "r", false, "Include the files in subfolders")
+	playlistCmd.Flags().StringVarP(&playlistWrite, "write", "w", "", "Write the playlist to this file instead of printing it")
+	playlistCmd.Flags().BoolVar(&playlistPlay, "play", false, "Play the playlist with the configured player")
+	playlistCmd.Flags().StringVar(&playlistRedirect, "redirect", "", "Base URL of a \"seedr serve redirect\" server to link to instead of download URLs")
+	playlistCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return internal.PlaylistFormats, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// playableFile reports whether a file is a video or a song, by the API's flags or its extension.
+func playableFile(f *seedr.File) bool {
+	if f.PlayVideo || f.PlayAudio {
+		return true
+	}
+	t := internal.FileType(f.Name)
+	return t == "video" || t == "audio"
+}
+
+// folderPlaylist lists the playable files in a folder, and with --recursive its subfolders, in natural
+// order of their paths and fetches a download URL for each, or links to them on the --redirect
+// server. Files whose URL can't be fetched are reported and left out.
+func folderPlaylist(ctx context.Context, folder SeedrObject) ([]internal.PlaylistItem, error) {
+	type entry struct {
+		path string
+		file seedr.File
+	}
+	var entries []entry
+	err := internal.Account.Walk(ctx, folder.id, func(p string, dir *seedr.Folder, file *seedr.File) error {
+		if dir != nil && !playlistRecursive {
+			return seedr.SkipDir
+		}
+		if file != nil && playableFile(file) {
+			entries = append(entries, entry{path: p, file: *file})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing '%s': %w", folder.path, err)
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return internal.NaturalLess(entries[i].path, entries[j].path) })
+
+	var items []internal.PlaylistItem
+	if playlistRedirect != "" {
+		for _, e := range entries {
+			fileID := fmt.Sprintf("%d", e.file.FolderFileID)
+			items = append(items, internal.PlaylistItem{Title: e.file.Name, URL: internal.RedirectURL(playlistRedirect, fileID, configuredRedirectToken())})
+		}
+		return items, nil
+	}
+	for i, e := range entries {
+		fmt.Fprintf(os.Stderr, "\rFetching links %d/%d...", i+1, len(entries))
+		fileResult, err := internal.Account.FetchFile(ctx, fmt.Sprintf("%d", e.file.FolderFileID))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "\nError fetching download URL for '%s': %v\n", e.path, err)
+			continue
+		}
+		items = append(items, internal.PlaylistItem{Title: e.file.Name, URL: fileResult.URL})
+	}
+	fmt.Fprintln(os.Stderr)
+	return items, nil
+}
+
+// writePlaylistFile writes the playlist to a file, replacing it if it exists.
+func writePlaylistFile(name, title, format string, items []internal.PlaylistItem) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := internal.WritePlaylist(f, format, title, items); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// playPlaylist writes the playlist to a temporary file and runs the player on it in this terminal.
+func playPlaylist(title, format string, items []internal.PlaylistItem) error {
+	tmp, err := os.CreateTemp("", "seedr-*."+format)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := internal.WritePlaylist(tmp, format, title, items); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	player, err := internal.FindHandler(internal.PlayerHandler)
+	if err != nil {
+		return err
+	}
+	c, err := player.Command(internal.HandlerVars{URL: tmp.Name(), Name: title})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Playing %d entries with %s...\n", len(items), c.Args[0])
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return c.Run()
+}
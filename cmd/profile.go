@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"seedr/internal"
+
+	"github.com/spf13/cobra"
+)
+
+// profileCmd represents the profile command
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage account profiles",
+	Long: `Profiles let you switch between several Seedr.cc accounts, e.g. a personal and a
+shared one. Each profile has its own token and local state. Commands use the
+profile given with --profile, then $SEEDR_PROFILE, then the one set with
+"profile use", and finally the "default" profile. Without a subcommand,
+profiles are listed.
+
+Examples:
+  seedr profile add shared
+  seedr --profile shared login
+  seedr profile use shared
+  SEEDR_PROFILE=default seedr list`,
+	PersistentPreRunE: skipLogin,
+	Run: func(cmd *cobra.Command, args []string) {
+		profileListCmd.Run(cmd, args)
+	},
+}
+
+// profileListCmd represents the profile list command
+var profileListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List profiles",
+	Long:    `This command lists the profiles. The active profile is marked with *.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running profile list command...")
+
+		names, err := internal.ListProfiles()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+		active := internal.ActiveProfile()
+
+		type profileInfo struct {
+			Name     string `json:"name"`
+			Active   bool   `json:"active"`
+			LoggedIn bool   `json:"logged_in"`
+		}
+		profiles := make([]profileInfo, 0, len(names))
+		for _, name := range names {
+			profiles = append(profiles, profileInfo{Name: name, Active: name == active, LoggedIn: internal.ProfileLoggedIn(name)})
+		}
+
+		if outputFormat == "json" {
+			if err := printJSON(profiles); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding profiles: %v\n", err)
+			}
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "\tPROFILE\tSTATUS")
+		for _, p := range profiles {
+			current, status := "", "not logged in"
+			if p.Active {
+				current = "*"
+			}
+			if p.LoggedIn {
+				status = "logged in"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", current, p.Name, status)
+		}
+		w.Flush()
+	},
+}
+
+// profileAddCmd represents the profile add command
+var profileAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a profile",
+	Long: `This command adds a profile. The new profile is not logged in; log in with
+"seedr --profile <name> login", or pass --login to do it right away.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running profile add command...")
+
+		if len(args) != 1 {
+			fmt.Println("Please specify the name of the profile to add.")
+			cmd.Help()
+			return
+		}
+		name := args[0]
+		if err := internal.AddProfile(name); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+		fmt.Printf("Added profile '%s'.\n", name)
+
+		if !profileAddLogin {
+			fmt.Printf("Log in with: seedr --profile %s login\n", name)
+			return
+		}
+		internal.SelectedProfile = name
+		if err := internal.LoginWithDeviceCode(context.Background()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error during login: %v\n", err)
+		}
+	},
+}
+
+// profileUseCmd represents the profile use command
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the profile used by default",
+	Long: `This command sets the profile used when neither --profile nor $SEEDR_PROFILE is
+given.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running profile use command...")
+
+		if len(args) != 1 {
+			fmt.Println("Please specify the name of the profile to use.")
+			cmd.Help()
+			return
+		}
+		if err := internal.UseProfile(args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+		fmt.Printf("Now using profile '%s'.\n", args[0])
+		if env := os.Getenv("SEEDR_PROFILE"); env != "" && env != args[0] {
+			fmt.Printf("Note: SEEDR_PROFILE is set to '%s' and takes precedence in this shell.\n", env)
+		}
+	},
+	ValidArgsFunction: completeProfileNames,
+}
+
+// profileRmCmd represents the profile rm command
+var profileRmCmd = &cobra.Command{
+	Use:     "rm <name>",
+	Aliases: []string{"remove"},
+	Short:   "Remove a profile",
+	Long: `This command removes a profile together with its saved token and local state.
+The token is not revoked on Seedr; run "seedr --profile <name> logout --revoke"
+first for that. The default profile cannot be removed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running profile rm command...")
+
+		if len(args) != 1 {
+			fmt.Println("Please specify the name of the profile to remove.")
+			cmd.Help()
+			return
+		}
+		name := args[0]
+		if !profileRmYes && internal.ProfileLoggedIn(name) {
+			if !stdinIsTerminal() {
+				fmt.Println("Refusing to remove a logged-in profile without confirmation; pass --yes to remove it non-interactively.")
+				return
+			}
+			if !confirm(fmt.Sprintf("Profile '%s' is logged in. Remove it and its token?", name)) {
+				fmt.Println("Nothing removed.")
+				return
+			}
+		}
+		if err := internal.RemoveProfile(name); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+		fmt.Printf("Removed profile '%s'.\n", name)
+	},
+	ValidArgsFunction: completeProfileNames,
+}
+
+var (
+	profileAddLogin bool
+	profileRmYes    bool
+)
+
+func init() {
+	RootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileListCmd, profileAddCmd, profileUseCmd, profileRmCmd)
+	addOutputFlag(profileListCmd, "text", "json")
+	profileAddCmd.Flags().BoolVar(&profileAddLogin, "login", false, "Log into the new profile with the device flow")
+	profileRmCmd.Flags().BoolVarP(&profileRmYes, "yes", "y", false, "Remove without asking for confirmation")
+}
+
+// completeProfileNames completes the names of existing profiles.
+func completeProfileNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names, err := internal.ListProfiles()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
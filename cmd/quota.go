@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"seedr/internal"
+	"seedr/pkg/seedr"
+)
+
+// sizedTorrent is a torrent about to be added, with its declared size (0 if unknown).
+type sizedTorrent struct {
+	name string
+	size int64
+}
+
+// preflightSpace warns when the torrents about to be added do not fit in the free space.
+// With --make-room it offers to delete the oldest top-level folders to make them fit.
+// Torrents are still submitted either way; Seedr queues what does not fit to the wishlist.
+func preflightSpace(ctx context.Context, torrents []sizedTorrent, targetFolderID string) {
+	var needed int64
+	for _, t := range torrents {
+		needed += t.size
+	}
+	if needed == 0 {
+		return // Nothing with a known size
+	}
+
+	mb, err := internal.Account.GetMemoryBandwidth(ctx)
+	if err != nil {
+		internal.Log.Debug("Skipping space check, could not fetch quota: %v", err)
+		return
+	}
+	free := int64(mb.SpaceMax - mb.SpaceUsed)
+	if needed <= free {
+		return
+	}
+
+	if len(torrents) == 1 {
+		fmt.Printf("Warning: '%s' needs %s but only %s of %s is free.\n",
+			torrents[0].name, internal.HumanReadableBytes(int(needed)), internal.HumanReadableBytes(int(max(free, 0))), internal.HumanReadableBytes(mb.SpaceMax))
+	} else {
+		fmt.Printf("Warning: these torrents need %s but only %s of %s is free.\n",
+			internal.HumanReadableBytes(int(needed)), internal.HumanReadableBytes(int(max(free, 0))), internal.HumanReadableBytes(mb.SpaceMax))
+	}
+	for _, t := range torrents {
+		if t.size > int64(mb.SpaceMax) {
+			fmt.Printf("Warning: '%s' (%s) is larger than your whole storage and can never be downloaded.\n", t.name, internal.HumanReadableBytes(int(t.size)))
+		}
+	}
+
+	if !addMakeRoom {
+		fmt.Println("Torrents that do not fit will be queued to the wishlist. Use --make-room to free space first.")
+		return
+	}
+	makeRoom(ctx, needed-free, targetFolderID)
+}
+
+// makeRoom proposes deleting the oldest top-level folders until at least shortfall bytes are freed,
+// and deletes them after confirmation. The folder being added to, and any folder containing it, is
+// never proposed.
+func makeRoom(ctx context.Context, shortfall int64, targetFolderID string) {
+	targetPath, err := folderPathByID(targetFolderID)
+	if err != nil {
+		fmt.Printf("Error: %v; nothing deleted.\n", err)
+		return
+	}
+	root, err := listFolder(ctx, "0")
+	if err != nil {
+		fmt.Printf("Error listing folders: %v\n", err)
+		return
+	}
+
+	candidates := oldestFirst(root.Folders)
+	var victims []SeedrObject
+	var freed int64
+	for _, f := range candidates {
+		if freed >= shortfall {
+			break
+		}
+		p := "/" + f.Name
+		if fmt.Sprintf("%d", f.ID) == targetFolderID || targetPath == p || strings.HasPrefix(targetPath, p+"/") {
+			continue
+		}
+		victims = append(victims, newFolderObject(f, "/", "0"))
+		freed += int64(f.Size)
+	}
+	if freed < shortfall {
+		fmt.Printf("Deleting every other folder would free only %s of the %s needed; nothing deleted.\n",
+			internal.HumanReadableBytes(int(freed)), internal.HumanReadableBytes(int(shortfall)))
+		return
+	}
+
+	fmt.Printf("\nDeleting these folders would free %s:\n", internal.HumanReadableBytes(int(freed)))
+	printRmTargets(victims)
+	if !stdinIsTerminal() {
+		fmt.Println("\nRefusing to delete without confirmation; free space with `seedr rm` instead.")
+		return
+	}
+	if !confirm(fmt.Sprintf("\nDelete %d folder(s) to make room?", len(victims))) {
+		fmt.Println("Nothing deleted.")
+		return
+	}
+
+	refs := make([]seedr.ItemRef, 0, len(victims))
+	for _, obj := range victims {
+		refs = append(refs, seedr.ItemRef{Type: obj.itemType, ID: obj.id})
+	}
+	if _, err := internal.Account.DeleteItems(ctx, refs); err != nil {
+		fmt.Printf("Error deleting folders: %v\n", err)
+		return
+	}
+	invalidateFolder("0")
+	fmt.Printf("Deleted %d folder(s).\n", len(victims))
+}
+
+// folderPathByID returns the absolute path of the folder with the given ID, or "/" for the root.
+func folderPathByID(folderID string) (string, error) {
+	if folderID == "" || folderID == "0" || folderID == "-1" {
+		return "/", nil
+	}
+	if _, err := FetchObjectDetails(); err != nil {
+		return "", fmt.Errorf("could not find the target folder: %w", err)
+	}
+	for _, obj := range allSeedrObjects {
+		if obj.isDir && obj.id == folderID {
+			return obj.path, nil
+		}
+	}
+	return "", fmt.Errorf("could not find the target folder %s", folderID)
+}
+
+// oldestFirst returns folders sorted by last update, oldest first. Folders without a date come last.
+func oldestFirst(folders []internal.SeedrFolder) []internal.SeedrFolder {
+	sorted := append([]internal.SeedrFolder(nil), folders...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].LastUpdate, sorted[j].LastUpdate
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.Before(*b)
+	})
+	return sorted
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"seedr/internal"
+
+	"github.com/spf13/cobra"
+)
+
+// recentCmd represents the recent command
+var recentCmd = &cobra.Command{
+	Use:   "recent",
+	Short: "List videos you have started watching",
+	Long: `This command lists the videos with a playback position saved on Seedr, most
+recently updated first. Positions are saved by Seedr's own players and the Kodi
+add-on; seedr only reads them, as the API has no documented way to save them.
+"seedr open" resumes videos from the saved position.
+
+Examples:
+  seedr recent
+  seedr recent -n 5
+  seedr recent -o paths | head -1 | seedr open -`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running recent command...")
+		ctx := context.Background()
+
+		videos, err := internal.RecentVideos(ctx, internal.Account)
+		if err != nil {
+			fmt.Printf("Error listing videos: %v\n", err)
+			return
+		}
+		if recentLimit > 0 && len(videos) > recentLimit {
+			videos = videos[:recentLimit]
+		}
+
+		switch outputFormat {
+		case "json":
+			out := make([]recentVideo, 0, len(videos))
+			for _, v := range videos {
+				out = append(out, recentVideo{
+					Path:       v.Path,
+					ID:         fmt.Sprintf("%d", v.File.FolderFileID),
+					Size:       v.File.Size,
+					Position:   int(v.Position.Seconds()),
+					LastUpdate: v.File.LastUpdate,
+				})
+			}
+			if err := printJSON(out); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding videos: %v\n", err)
+			}
+		case "paths":
+			for _, v := range videos {
+				fmt.Println(v.Path)
+			}
+		default:
+			if len(videos) == 0 {
+				fmt.Println("No videos in progress.")
+				return
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "POSITION\tSIZE\tID\tPATH")
+			for _, v := range videos {
+				fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", internal.FormatPosition(v.Position), internal.HumanReadableBytes(v.File.Size), v.File.FolderFileID, v.Path)
+			}
+			w.Flush()
+		}
+	},
+}
+
+// recentVideo is a partly watched video as printed with --output json.
+type recentVideo struct {
+	Path       string     `json:"path"`
+	ID         string     `json:"id"`
+	Size       int        `json:"size"`
+	Position   int        `json:"position"` // Seconds
+	LastUpdate *time.Time `json:"last_update,omitempty"`
+}
+
+var recentLimit int
+
+func init() {
+	RootCmd.AddCommand(recentCmd)
+	recentCmd.Flags().IntVarP(&recentLimit, "limit", "n", 0, "Only list this many videos (0 for all)")
+	addOutputFlag(recentCmd, "text", "json", "paths")
+}
@@ -0,0 +1,323 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"seedr/internal"
+
+	"github.com/spf13/cobra"
+)
+
+// renameCmd represents the rename command
+var renameCmd = &cobra.Command{
+	Use:   "rename --regex 's/pattern/replacement/[gi]' <path>...",
+	Short: "Rename many files or folders with a sed-style pattern",
+	Long: `This command renames every matching file or folder using a sed-style substitution.
+Paths may contain shell-style wildcards, which are expanded on Seedr (quote them
+so your shell does not expand them locally).
+
+The old and new names are previewed as a table and applied after confirmation.
+Renames that would collide with another item in the same folder are reported and
+nothing is sent.
+
+Examples:
+  seedr rename --regex 's/\.1080p.*//' '/Movies/*'
+  seedr rename --regex 's/_/ /g' '/TV/Show/Season 1/*' --yes`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running rename command...")
+		ctx := context.Background()
+
+		if len(args) == 0 || renameRegex == "" {
+			fmt.Println("Please provide a --regex substitution and at least one path.")
+			cmd.Help()
+			return
+		}
+
+		expr, err := parseSedExpr(renameRegex)
+		if err != nil {
+			fmt.Printf("Error parsing --regex: %v\n", err)
+			return
+		}
+
+		objects, err := expandArgs(ctx, args)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		var plans []renamePlan
+		var invalid []string
+		for _, obj := range objects {
+			newName := expr.apply(obj.name)
+			if newName == obj.name {
+				continue
+			}
+			if err := checkItemName(newName); err != nil {
+				invalid = append(invalid, fmt.Sprintf("'%s' -> '%s': %v", obj.path, newName, err))
+				continue
+			}
+			plans = append(plans, renamePlan{obj: obj, newName: newName})
+		}
+		if len(invalid) > 0 {
+			fmt.Println("Rename aborted, invalid new names:")
+			for _, msg := range invalid {
+				fmt.Printf("  %s\n", msg)
+			}
+			return
+		}
+		if len(plans) == 0 {
+			fmt.Println("Nothing to rename.")
+			return
+		}
+
+		printRenamePlans(plans)
+
+		conflicts, err := findRenameConflicts(ctx, plans)
+		if err != nil {
+			fmt.Printf("Error checking for conflicts: %v\n", err)
+			return
+		}
+		if len(conflicts) > 0 {
+			fmt.Println("\nRename aborted, conflicts found:")
+			for _, c := range conflicts {
+				fmt.Printf("  %s\n", c)
+			}
+			return
+		}
+
+		if !renameYes && !confirm(fmt.Sprintf("\nRename %d item(s)?", len(plans))) {
+			fmt.Println("Rename cancelled.")
+			return
+		}
+
+		failed := 0
+		for _, plan := range plans {
+			if err := renameObject(ctx, plan.obj, plan.newName); err != nil {
+				fmt.Printf("Error renaming '%s': %v\n", plan.obj.path, err)
+				failed++
+				continue
+			}
+			fmt.Printf("Renamed '%s' -> '%s'.\n", plan.obj.path, plan.newName)
+		}
+		if failed > 0 {
+			fmt.Printf("%d of %d renames failed.\n", failed, len(plans))
+		}
+	},
+	ValidArgsFunction: completerenamePrompt,
+}
+
+var (
+	renameRegex string
+	renameYes   bool
+)
+
+func init() {
+	RootCmd.AddCommand(renameCmd)
+	renameCmd.Flags().StringVarP(&renameRegex, "regex", "e", "", "sed-style substitution, e.g. 's/\\.1080p.*//'")
+	renameCmd.Flags().BoolVarP(&renameYes, "yes", "y", false, "Apply the renames without asking for confirmation")
+}
+
+func completerenamePrompt(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return CompleteSeedrObjectPrompt(cmd, nil, toComplete)
+}
+
+// renamePlan is a single pending rename.
+type renamePlan struct {
+	obj     SeedrObject
+	newName string
+}
+
+// checkItemName returns an error if name can't be the name of a file or folder.
+func checkItemName(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return fmt.Errorf("the name is empty")
+	case name == "." || name == "..":
+		return fmt.Errorf("'%s' is reserved", name)
+	case strings.Contains(name, "/"):
+		return fmt.Errorf("names can't contain '/'")
+	}
+	return nil
+}
+
+// printRenamePlans prints a table of old and new names.
+func printRenamePlans(plans []renamePlan) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "OLD\tNEW")
+	for _, plan := range plans {
+		fmt.Fprintf(w, "%s\t%s\n", plan.obj.path, plan.newName)
+	}
+	w.Flush()
+}
+
+// findRenameConflicts checks every target folder for names that would clash after renaming.
+// A new name conflicts if it is already taken by another item in the same folder, or if
+// two renames in the same folder produce the same name.
+func findRenameConflicts(ctx context.Context, plans []renamePlan) ([]string, error) {
+	var conflicts []string
+
+	byParent := make(map[string][]renamePlan)
+	var parentOrder []string
+	for _, plan := range plans {
+		if _, ok := byParent[plan.obj.parentID]; !ok {
+			parentOrder = append(parentOrder, plan.obj.parentID)
+		}
+		byParent[plan.obj.parentID] = append(byParent[plan.obj.parentID], plan)
+	}
+
+	for _, parentID := range parentOrder {
+		contents, err := listFolder(ctx, parentID)
+		if err != nil {
+			return nil, err
+		}
+		existing := make(map[string]bool)
+		for _, f := range contents.Folders {
+			existing[f.Name] = true
+		}
+		for _, f := range contents.Files {
+			existing[f.Name] = true
+		}
+		for _, t := range contents.Torrents {
+			existing[t.Name] = true
+		}
+
+		claimed := make(map[string]string) // new name -> path of the item claiming it
+		for _, plan := range byParent[parentID] {
+			if existing[plan.newName] {
+				conflicts = append(conflicts, fmt.Sprintf("'%s' -> '%s': an item with that name already exists", plan.obj.path, plan.newName))
+				continue
+			}
+			if other, ok := claimed[plan.newName]; ok {
+				conflicts = append(conflicts, fmt.Sprintf("'%s' -> '%s': also the new name of '%s'", plan.obj.path, plan.newName, other))
+				continue
+			}
+			claimed[plan.newName] = plan.obj.path
+		}
+	}
+	return conflicts, nil
+}
+
+// renameObject renames a single file or folder and drops the stale listing of its parent.
+func renameObject(ctx context.Context, obj SeedrObject, newName string) error {
+	var err error
+	switch obj.itemType {
+	case "folder":
+		_, err = internal.Account.RenameFolder(ctx, obj.id, newName)
+	case "file":
+		_, err = internal.Account.RenameFile(ctx, obj.id, newName)
+	default:
+		return fmt.Errorf("cannot rename a %s", obj.itemType)
+	}
+	if err != nil {
+		return err
+	}
+	invalidateFolder(obj.parentID)
+	return nil
+}
+
+// sedExpr is a parsed sed-style substitution: s/pattern/replacement/flags.
+type sedExpr struct {
+	re          *regexp.Regexp
+	replacement string // In regexp.Expand syntax
+	global      bool
+}
+
+// parseSedExpr parses an expression such as 's/\.1080p.*//' or 's|_| |g'.
+// Any character may be used as the delimiter; supported flags are g (all matches) and i (ignore case).
+// In the replacement, & and \1..\9 refer to the whole match and capture groups, as in sed.
+func parseSedExpr(expr string) (*sedExpr, error) {
+	if len(expr) < 2 || expr[0] != 's' {
+		return nil, fmt.Errorf("expression must look like s/pattern/replacement/")
+	}
+	delim := expr[1]
+
+	var parts []string
+	var current strings.Builder
+	for i := 2; i < len(expr); i++ {
+		c := expr[i]
+		if c == '\\' && i+1 < len(expr) && expr[i+1] == delim {
+			current.WriteByte(delim) // Escaped delimiter
+			i++
+			continue
+		}
+		if c == '\\' && i+1 < len(expr) {
+			current.WriteByte(c)
+			current.WriteByte(expr[i+1])
+			i++
+			continue
+		}
+		if c == delim {
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteByte(c)
+	}
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("expression must look like s%cpattern%creplacement%c", delim, delim, delim)
+	}
+	flags := current.String()
+
+	pattern := parts[0]
+	global := false
+	for _, f := range flags {
+		switch f {
+		case 'g':
+			global = true
+		case 'i':
+			pattern = "(?i)" + pattern
+		default:
+			return nil, fmt.Errorf("unsupported flag '%c'", f)
+		}
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &sedExpr{re: re, replacement: sedReplacementToGo(parts[1]), global: global}, nil
+}
+
+// sedReplacementToGo converts sed replacement syntax (&, \1) into regexp.Expand syntax (${0}, ${1}).
+func sedReplacementToGo(repl string) string {
+	var b strings.Builder
+	for i := 0; i < len(repl); i++ {
+		c := repl[i]
+		switch {
+		case c == '\\' && i+1 < len(repl) && repl[i+1] >= '0' && repl[i+1] <= '9':
+			b.WriteString("${" + string(repl[i+1]) + "}")
+			i++
+		case c == '\\' && i+1 < len(repl):
+			b.WriteByte(repl[i+1])
+			i++
+		case c == '&':
+			b.WriteString("${0}")
+		case c == '$':
+			b.WriteString("$$")
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// apply runs the substitution on s.
+func (e *sedExpr) apply(s string) string {
+	if e.global {
+		return e.re.ReplaceAllString(s, e.replacement)
+	}
+	loc := e.re.FindStringSubmatchIndex(s)
+	if loc == nil {
+		return s
+	}
+	var out []byte
+	out = append(out, s[:loc[0]]...)
+	out = e.re.ExpandString(out, e.replacement, s, loc)
+	out = append(out, s[loc[1]:]...)
+	return string(out)
+}
@@ -3,70 +3,223 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
 	"seedr/internal"
+	"seedr/pkg/seedr"
+
 	"github.com/spf13/cobra"
 )
 
+// rmBatchSize is the maximum number of items sent in a single delete request.
+const rmBatchSize = 50
+
 // rmCmd represents the rm command
 var rmCmd = &cobra.Command{
-	Use:     "rm [item-name]",
+	Use:     "rm <path>...",
 	Aliases: []string{"r"},
-	Short:   "Delete a file or folder by name",
-	Long:    `This command deletes a specified file or folder from your Seedr.cc account using its name.`,
+	Short:   "Delete files, folders or torrents",
+	Long: `This command deletes files, folders and torrents from your Seedr.cc account.
+Items can be given by name, as absolute paths (e.g. /Movies/x.mkv) or as shell-style
+patterns. Quote patterns so your shell does not expand them locally. Pass "-" to
+read paths from stdin, one per line (deleting from a pipe requires --yes).
+
+Matches can be narrowed with --older-than (based on the last update time),
+--larger-than and --type. The items to delete are listed and must be confirmed,
+unless --yes is given. Use --dry-run to only print what would be deleted.
+
+Examples:
+  seedr rm /Movies/old.mkv
+  seedr rm '/Downloads/*.nfo' --yes
+  seedr rm '/*' --older-than 14d --type folder --dry-run
+  seedr rm '/TV/*/*' --larger-than 4GB`,
 
 	Run: func(cmd *cobra.Command, args []string) {
 		internal.Log.Debug("Running rm command...\n")
+		ctx := context.Background()
 
 		if len(args) == 0 {
-			fmt.Println("Please specify the name of the file or folder you want to remove.")
+			fmt.Println("Please specify the files or folders you want to remove.")
 			cmd.Help()
 			return
 		}
-		if len(args) > 1 {
-			fmt.Println("Please specify only one item name at a time.")
+
+		args, err := readArgs(args)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
 			return
 		}
 
-		itemName := args[0]
-		internal.Log.Debug("Trying to Fetch ID for %s to remove", itemName)
-		
-		// Ensure cache is populated
-		_, err := FetchObjectDetails()
+		filter, err := newRmFilter()
 		if err != nil {
-			fmt.Printf("Error fetching Seedr objects for lookup: %v\n", err)
+			fmt.Printf("Error: %v\n", err)
 			return
 		}
 
-		obj, ok := allSeedrObjects[itemName]
-		if !ok {
-			fmt.Printf("Error: Item '%s' not found in your Seedr account. Please check the name and try again.\n", itemName)
+		objects, err := expandArgs(ctx, args)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
 			return
 		}
 
-		ctx := context.Background()
-		if obj.isDir {
-			_, err = internal.Account.DeleteFolder(ctx, obj.id)
-			if err != nil {
-				fmt.Printf("Error deleting folder %s: %v\n", itemName, err)
+		var targets []SeedrObject
+		for _, obj := range objects {
+			if filter.matches(obj) {
+				targets = append(targets, obj)
+			}
+		}
+		targets = pruneNested(targets)
+		if len(targets) == 0 {
+			fmt.Println("Nothing matches, no items deleted.")
+			return
+		}
+
+		printRmTargets(targets)
+
+		if rmDryRun {
+			fmt.Printf("\nDry run: %d item(s) would be deleted.\n", len(targets))
+			return
+		}
+		if !rmYes {
+			if !stdinIsTerminal() {
+				fmt.Println("\nRefusing to delete without confirmation; pass --yes to delete non-interactively.")
 				return
 			}
-			fmt.Printf("Successfully deleted folder '%s'.\n", itemName)
-		} else {
-			_, err = internal.Account.DeleteFile(ctx, obj.id)
-			if err != nil {
-				fmt.Printf("Error deleting file %s: %v\n", itemName, err)
+			if !confirm(fmt.Sprintf("\nDelete %d item(s)?", len(targets))) {
+				fmt.Println("Deletion cancelled.")
 				return
 			}
-			fmt.Printf("Successfully deleted file '%s'.\n", itemName)
 		}
+
+		deleted := 0
+		for start := 0; start < len(targets); start += rmBatchSize {
+			end := min(start+rmBatchSize, len(targets))
+			batch := targets[start:end]
+
+			refs := make([]seedr.ItemRef, 0, len(batch))
+			for _, obj := range batch {
+				refs = append(refs, seedr.ItemRef{Type: obj.itemType, ID: obj.id})
+			}
+			if _, err := internal.Account.DeleteItems(ctx, refs); err != nil {
+				fmt.Printf("Error deleting items %d-%d: %v\n", start+1, end, err)
+				continue
+			}
+			for _, obj := range batch {
+				invalidateFolder(obj.parentID)
+			}
+			deleted += len(batch)
+		}
+		fmt.Printf("Successfully deleted %d of %d item(s).\n", deleted, len(targets))
 	},
 	ValidArgsFunction: completermPrompt,
 }
 
+var (
+	rmOlderThan  string
+	rmLargerThan string
+	rmType       string
+	rmDryRun     bool
+	rmYes        bool
+)
+
 func init() {
 	RootCmd.AddCommand(rmCmd)
+	rmCmd.Flags().StringVar(&rmOlderThan, "older-than", "", "Only delete items last updated longer ago than this (e.g. 14d, 2w, 36h)")
+	rmCmd.Flags().StringVar(&rmLargerThan, "larger-than", "", "Only delete items larger than this (e.g. 4GB, 700MiB)")
+	rmCmd.Flags().StringVar(&rmType, "type", "", "Only delete items of this type (file, folder or torrent)")
+	rmCmd.Flags().BoolVarP(&rmDryRun, "dry-run", "n", false, "Print what would be deleted without deleting anything")
+	rmCmd.Flags().BoolVarP(&rmYes, "yes", "y", false, "Delete without asking for confirmation")
+
+	rmCmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"file", "folder", "torrent"}, cobra.ShellCompDirectiveNoFileComp
+	})
 }
 
 func completermPrompt(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-	return CompleteSeedrObjectPrompt(cmd, args, toComplete)
+	return CompleteSeedrObjectPrompt(cmd, nil, toComplete)
+}
+
+// rmFilter holds the parsed --older-than, --larger-than and --type filters.
+type rmFilter struct {
+	olderThan  time.Duration
+	largerThan int
+	itemType   string
+}
+
+// newRmFilter parses the rm filter flags.
+func newRmFilter() (rmFilter, error) {
+	var f rmFilter
+	var err error
+	if rmOlderThan != "" {
+		if f.olderThan, err = parseAge(rmOlderThan); err != nil {
+			return f, err
+		}
+	}
+	if rmLargerThan != "" {
+		if f.largerThan, err = parseSize(rmLargerThan); err != nil {
+			return f, err
+		}
+	}
+	switch rmType {
+	case "", "file", "folder", "torrent":
+		f.itemType = rmType
+	default:
+		return f, fmt.Errorf("invalid --type '%s', expected file, folder or torrent", rmType)
+	}
+	return f, nil
+}
+
+// matches reports whether an object passes every configured filter.
+// Items without a known last update time never match --older-than.
+func (f rmFilter) matches(obj SeedrObject) bool {
+	if obj.id == "0" {
+		return false // Never delete the root itself
+	}
+	if f.itemType != "" && obj.itemType != f.itemType {
+		return false
+	}
+	if f.largerThan > 0 && obj.size <= f.largerThan {
+		return false
+	}
+	if f.olderThan > 0 {
+		if obj.lastUpdate == nil || time.Since(*obj.lastUpdate) <= f.olderThan {
+			return false
+		}
+	}
+	return true
+}
+
+// pruneNested drops items that live inside a folder that is itself being deleted.
+func pruneNested(objects []SeedrObject) []SeedrObject {
+	var pruned []SeedrObject
+	for _, obj := range objects {
+		nested := false
+		for _, other := range objects {
+			if other.isDir && other.path != obj.path && strings.HasPrefix(obj.path, other.path+"/") {
+				nested = true
+				break
+			}
+		}
+		if !nested {
+			pruned = append(pruned, obj)
+		}
+	}
+	return pruned
+}
+
+// printRmTargets prints the items about to be deleted.
+func printRmTargets(targets []SeedrObject) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tSIZE\tLAST UPDATE\tPATH")
+	for _, obj := range targets {
+		lastUpdate := "N/A"
+		if obj.lastUpdate != nil {
+			lastUpdate = obj.lastUpdate.Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", obj.itemType, internal.HumanReadableBytes(obj.size), lastUpdate, obj.path)
+	}
+	w.Flush()
 }
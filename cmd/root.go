@@ -65,6 +65,16 @@ func init() {
 	// Cobra supports persistent flags, which, if defined here,
 	// will be available to all subcommands in the application.
 	RootCmd.PersistentFlags().BoolVarP(&DebugMode, "debug", "d", false, "Enable debug logging")
+	RootCmd.PersistentFlags().StringVar(&internal.SelectedProfile, "profile", "", "Account profile to use (default $SEEDR_PROFILE, then the profile set with `profile use`)")
+	RootCmd.RegisterFlagCompletionFunc("profile", completeProfileNames)
+	cobra.OnInitialize(initConfig)
+}
+
+// skipLogin replaces the root PersistentPreRunE on commands that must not start the device flow,
+// such as login itself or commands that work offline. It only sets up the logger.
+func skipLogin(cmd *cobra.Command, args []string) error {
+	internal.Log = internal.NewLogger(DebugMode, false)
+	return nil
 }
 
 // Function to start TUI. This function will be defined in cli.go and passed to cmd.
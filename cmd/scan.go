@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"seedr/internal"
+	"seedr/pkg/seedr"
+)
+
+// promptScannedTorrent lists scan results and asks for a single number on stdin.
+func promptScannedTorrent(torrents []seedr.ScannedTorrent) (seedr.ScannedTorrent, bool) {
+	// Simple TUI for selection
+	fmt.Println("Torrents found on page:")
+	for i, t := range torrents {
+		fmt.Printf("[%d] %s (Size: %s, Magnet: %s)\n", i+1, t.Title, internal.HumanReadableBytes(t.Size), t.Magnet)
+	}
+	fmt.Print("Enter the number of the torrent to add (or 0 to cancel): ")
+	var selection int
+	_, err := fmt.Scanln(&selection)
+	if err != nil || selection < 0 || selection > len(torrents) {
+		fmt.Println("Invalid selection. Cancelling add operation.")
+		return seedr.ScannedTorrent{}, false
+	}
+	if selection == 0 {
+		fmt.Println("Add operation cancelled.")
+		return seedr.ScannedTorrent{}, false
+	}
+	return torrents[selection-1], true
+}
+
+// scanSelection holds the parsed scan-page selection flags.
+type scanSelection struct {
+	indexes map[int]bool // 1-based positions in the scan list
+	all     bool
+	best    bool
+	match   *regexp.Regexp
+	minSize int
+	maxSize int
+}
+
+// indexedTorrent is a scan result together with its 1-based position in the scan list.
+type indexedTorrent struct {
+	Index int `json:"index"`
+	seedr.ScannedTorrent
+}
+
+// newScanSelection parses the --select, --all, --best, --match and size flags for a page with
+// count scan results.
+func newScanSelection(count int) (scanSelection, error) {
+	s := scanSelection{all: addAll, best: addBest}
+	var err error
+	if addSelect != "" {
+		if s.indexes, err = parseIndexList(addSelect, count); err != nil {
+			return s, err
+		}
+	}
+	if addMatch != "" {
+		if s.match, err = regexp.Compile(addMatch); err != nil {
+			return s, fmt.Errorf("invalid --match regex: %w", err)
+		}
+	}
+	if addMinSize != "" {
+		if s.minSize, err = parseSize(addMinSize); err != nil {
+			return s, err
+		}
+	}
+	if addMaxSize != "" {
+		if s.maxSize, err = parseSize(addMaxSize); err != nil {
+			return s, err
+		}
+	}
+	return s, nil
+}
+
+// active reports whether any selection flag was given, so no prompt is needed.
+func (s scanSelection) active() bool {
+	return s.indexes != nil || s.all || s.best || s.match != nil || s.minSize > 0 || s.maxSize > 0
+}
+
+// filter returns the scan results that pass --select, --match and the size limits.
+func (s scanSelection) filter(torrents []seedr.ScannedTorrent) []indexedTorrent {
+	filtered := []indexedTorrent{}
+	for i, t := range torrents {
+		if s.indexes != nil && !s.indexes[i+1] {
+			continue
+		}
+		if s.match != nil && !s.match.MatchString(t.Title) {
+			continue
+		}
+		if s.minSize > 0 && t.Size < s.minSize {
+			continue
+		}
+		if s.maxSize > 0 && t.Size > s.maxSize {
+			continue
+		}
+		filtered = append(filtered, indexedTorrent{Index: i + 1, ScannedTorrent: t})
+	}
+	return filtered
+}
+
+// choose applies the selection to the scan results.
+func (s scanSelection) choose(torrents []seedr.ScannedTorrent) []seedr.ScannedTorrent {
+	filtered := s.filter(torrents)
+	if s.best && len(filtered) > 0 {
+		sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].Pct > filtered[j].Pct })
+		filtered = filtered[:1]
+	}
+
+	chosen := make([]seedr.ScannedTorrent, 0, len(filtered))
+	for _, t := range filtered {
+		chosen = append(chosen, t.ScannedTorrent)
+	}
+	return chosen
+}
+
+// parseIndexList parses a list of 1-based numbers and ranges such as "1,3-5", each of which must
+// be at most count.
+func parseIndexList(list string, count int) (map[int]bool, error) {
+	indexes := make(map[int]bool)
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(lo))
+		if err != nil || start < 1 {
+			return nil, fmt.Errorf("invalid --select entry '%s'", part)
+		}
+		end := start
+		if isRange {
+			end, err = strconv.Atoi(strings.TrimSpace(hi))
+			if err != nil || end < start {
+				return nil, fmt.Errorf("invalid --select range '%s'", part)
+			}
+		}
+		if end > count {
+			return nil, fmt.Errorf("--select '%s' is out of range, the page has %d result(s)", part, count)
+		}
+		for i := start; i <= end; i++ {
+			indexes[i] = true
+		}
+	}
+	if len(indexes) == 0 {
+		return nil, fmt.Errorf("--select needs at least one number")
+	}
+	return indexes, nil
+}
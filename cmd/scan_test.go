@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseIndexList(t *testing.T) {
+	tests := []struct {
+		list string
+		want []int // nil when the list is rejected
+	}{
+		{"1", []int{1}},
+		{"1,3", []int{1, 3}},
+		{"2-4", []int{2, 3, 4}},
+		{" 1 , 4 - 5 ,", []int{1, 4, 5}},
+		{"1-3,2-5", []int{1, 2, 3, 4, 5}},
+		{"5-5", []int{5}},
+		{"10", []int{10}},
+		{"1-10", []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+
+		{"", nil},
+		{",", nil},
+		{"0", nil},
+		{"0-3", nil},
+		{"-1", nil},
+		{"3-1", nil},
+		{"1-", nil},
+		{"a", nil},
+		{"1-b", nil},
+		{"11", nil},
+		{"9-11", nil},
+		{"1-9223372036854775807", nil},
+		{"9223372036854775807", nil},
+		{"1-99999999999999999999", nil},
+	}
+	for _, tt := range tests {
+		got, err := parseIndexList(tt.list, 10)
+		if tt.want == nil {
+			if err == nil {
+				t.Errorf("parseIndexList(%q) = %v, want an error", tt.list, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseIndexList(%q): %v", tt.list, err)
+			continue
+		}
+		want := make(map[int]bool)
+		for _, i := range tt.want {
+			want[i] = true
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("parseIndexList(%q) = %v, want %v", tt.list, got, want)
+		}
+	}
+}
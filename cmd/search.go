@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"text/tabwriter"
+
+	"seedr/internal"
+
+	"github.com/spf13/cobra"
+)
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use:     "search <query>",
+	Aliases: []string{"find"},
+	Short:   "Search files and folders on Seedr",
+	Long: `This command searches your Seedr.cc account and prints every matching file and
+folder with its full path, size and ID.
+
+Use --output paths to print one path per line, which can be piped into other
+commands that read paths from stdin with "-".
+
+Examples:
+  seedr search "1080p"
+  seedr search show --type folder --output json
+  seedr search .nfo --type file -o paths | seedr rm - --yes
+  seedr search trailer -o paths | seedr get -`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running search command...")
+		ctx := context.Background()
+
+		if len(args) != 1 {
+			fmt.Println("Please specify a single search query.")
+			cmd.Help()
+			return
+		}
+
+		switch searchType {
+		case "", "file", "folder":
+		default:
+			fmt.Printf("Error: invalid --type '%s', expected file or folder.\n", searchType)
+			return
+		}
+
+		result, err := internal.Account.SearchFiles(ctx, args[0])
+		if err != nil {
+			fmt.Printf("Error searching for '%s': %v\n", args[0], err)
+			return
+		}
+
+		// Search results only carry parent folder IDs; walk the tree once to learn every folder's path.
+		if _, err := FetchObjectDetails(); err != nil {
+			fmt.Printf("Error fetching Seedr objects for path lookup: %v\n", err)
+			return
+		}
+
+		var matches []searchMatch
+		if searchType != "file" {
+			for _, f := range result.Folders {
+				p, ok := folderPaths[fmt.Sprintf("%d", f.ID)]
+				if !ok {
+					p = "/" + f.Fullname
+				}
+				matches = append(matches, searchMatch{Type: "folder", Path: p, Name: f.Name, ID: fmt.Sprintf("%d", f.ID), Size: f.Size})
+			}
+		}
+		if searchType != "folder" {
+			for _, f := range result.Files {
+				m := searchMatch{Type: "file", Name: f.Name, ID: fmt.Sprintf("%d", f.FolderFileID), Size: f.Size}
+				// Files whose folder is unknown keep an empty path, so they can't be piped to other commands
+				if parent, ok := folderPaths[fmt.Sprintf("%d", f.FolderID)]; ok {
+					m.Path = path.Join(parent, f.Name)
+				}
+				matches = append(matches, m)
+			}
+		}
+
+		switch outputFormat {
+		case "json":
+			if matches == nil {
+				matches = []searchMatch{}
+			}
+			if err := printJSON(matches); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding results: %v\n", err)
+			}
+		case "paths":
+			unresolved := 0
+			for _, m := range matches {
+				if m.Path == "" {
+					unresolved++
+					continue
+				}
+				fmt.Println(m.Path)
+			}
+			if unresolved > 0 {
+				fmt.Fprintf(os.Stderr, "Left out %d match(es) whose folder could not be found; see the table output for their IDs.\n", unresolved)
+			}
+		default:
+			if len(matches) == 0 {
+				fmt.Printf("No matches for '%s'.\n", args[0])
+				return
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "TYPE\tSIZE\tID\tPATH")
+			for _, m := range matches {
+				p := m.Path
+				if p == "" {
+					p = m.Name + " (folder unknown)"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", m.Type, internal.HumanReadableBytes(m.Size), m.ID, p)
+			}
+			w.Flush()
+		}
+	},
+}
+
+// searchMatch is a single search result with its resolved location. Path is empty for files whose
+// folder could not be found.
+type searchMatch struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+	Name string `json:"name"`
+	ID   string `json:"id"`
+	Size int    `json:"size"`
+}
+
+var searchType string
+
+func init() {
+	RootCmd.AddCommand(searchCmd)
+	searchCmd.Flags().StringVar(&searchType, "type", "", "Only show matches of this type (file or folder)")
+	addOutputFlag(searchCmd, "text", "json", "paths")
+}
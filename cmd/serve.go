@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"seedr/internal"
+
+	"github.com/spf13/cobra"
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run local servers for your Seedr files",
+	Long:  `Commands for running local servers that make your Seedr files available to other programs.`,
+}
+
+// serveRedirectCmd represents the serve redirect command
+var serveRedirectCmd = &cobra.Command{
+	Use:   "redirect",
+	Short: "Serve stable links that redirect to fresh download URLs",
+	Long: `This command runs an HTTP server that gives your files links that don't
+expire, for playlists, bookmarks and .strm files. Each request is redirected
+(302) to a freshly fetched download URL:
+
+  /f/<id>           the file with this ID, as printed by "seedr search"
+  /p/<path>         the file at this path, e.g. /p/Movies/Big.Buck.Bunny.mkv
+
+Download URLs and folder listings are cached for --ttl. With --token, requests
+must send "Authorization: Bearer <token>" or add "?token=<token>" to the URL.
+The token can also be set as serve.redirect.token in the config file or in
+SEEDR_SERVE_REDIRECT_TOKEN, which keeps it out of the process list. The server
+listens on localhost by default; set a token before listening on other
+addresses.
+
+Examples:
+  seedr serve redirect
+  seedr serve redirect --listen 0.0.0.0:8080 --token "$(openssl rand -hex 16)"
+  mpv http://127.0.0.1:8080/p/Movies/Big.Buck.Bunny.mkv`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running serve redirect command...")
+
+		if redirectTTL <= 0 {
+			fmt.Println("Error: --ttl must be positive.")
+			return
+		}
+		if redirectToken == "" && !isLoopback(redirectListen) {
+			fmt.Fprintf(os.Stderr, "Warning: listening on %s without --token; anyone who can reach it can stream your files.\n", redirectListen)
+		}
+
+		redirector := internal.NewRedirector(internal.Account, redirectTTL, redirectToken)
+		redirector.Log = func(format string, v ...any) {
+			fmt.Printf("%s "+format+"\n", append([]any{time.Now().Format("2006-01-02 15:04:05")}, v...)...)
+		}
+		server := &http.Server{
+			Addr:              redirectListen,
+			Handler:           redirector,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			server.Shutdown(shutdownCtx)
+		}()
+
+		fmt.Printf("Serving redirects on http://%s/ (Ctrl+C to stop)\n", redirectListen)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("Error: %v\n", err)
+		}
+	},
+}
+
+var (
+	redirectListen string
+	redirectTTL    time.Duration
+	redirectToken  string
+)
+
+func init() {
+	RootCmd.AddCommand(serveCmd)
+	serveCmd.AddCommand(serveRedirectCmd)
+	serveRedirectCmd.Flags().StringVar(&redirectListen, "listen", "127.0.0.1:8080", "Address to listen on")
+	serveRedirectCmd.Flags().DurationVar(&redirectTTL, "ttl", 10*time.Minute, "How long to reuse a download URL or folder listing")
+	serveRedirectCmd.Flags().StringVar(&redirectToken, "token", "", "Require this bearer token on every request")
+}
+
+// isLoopback reports whether a listen address only accepts connections from this machine.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// configuredRedirectToken returns the token set for the redirect server in the config file or the
+// environment, for commands that write links to it.
+func configuredRedirectToken() string {
+	v, _ := internal.Conf.Lookup("serve.redirect.token")
+	return v.String()
+}
@@ -3,7 +3,14 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
 	"seedr/internal" // Assuming internal is where Seedr client and models are
+	"seedr/pkg/seedr"
+
 	"github.com/spf13/cobra"
 )
 
@@ -11,8 +18,9 @@ import (
 var settingsCmd = &cobra.Command{
 	Use:     "settings",
 	Aliases: []string{"s"},
-	Short:   "Display Seedr account settings",
-	Long:    `This command fetches and displays your Seedr.cc account settings, including username, space usage, and bandwidth.`, 
+	Short:   "Display and change Seedr account settings",
+	Long: `This command fetches and displays your Seedr.cc account settings, including username, space usage, and bandwidth.
+Use "settings get" to show every setting and "settings set" to change them.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		internal.Log.Debug("Running settings command...")
 		ctx := context.Background()
@@ -25,8 +33,101 @@ var settingsCmd = &cobra.Command{
 	},
 }
 
+// settingsGetCmd represents the settings get command
+var settingsGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Show account settings",
+	Long: `This command shows the editable account settings along with the usage figures.
+Give a key to print only that setting's value.
+
+Examples:
+  seedr settings get
+  seedr settings get subtitles_language
+  seedr settings get --output json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running settings get command...")
+		ctx := context.Background()
+
+		settings, err := internal.Account.GetSettings(ctx)
+		if err != nil {
+			fmt.Printf("Error getting settings: %v\n", err)
+			return
+		}
+		values := accountSettingValues(settings.Settings)
+
+		if len(args) == 1 {
+			value, ok := values[args[0]]
+			if !ok {
+				fmt.Printf("Error: unknown setting '%s', expected one of: %s.\n", args[0], strings.Join(settingKeys(), ", "))
+				return
+			}
+			fmt.Println(value)
+			return
+		}
+
+		if outputFormat == "json" {
+			if err := printJSON(settings); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding settings: %v\n", err)
+			}
+			return
+		}
+		printSeedrSettings(settings)
+		fmt.Println()
+		for _, key := range settingKeys() {
+			fmt.Printf("%s = %s\n", key, values[key])
+		}
+	},
+	ValidArgsFunction: completeSettingKeys,
+}
+
+// settingsSetCmd represents the settings set command
+var settingsSetCmd = &cobra.Command{
+	Use:   "set <key=value>...",
+	Short: "Change account settings",
+	Long: `This command changes account settings. The editable keys are:
+  allow_remote_access   true or false
+  site_language         language code, e.g. en
+  subtitles_language    language code, e.g. en
+  email_announcements   true or false
+  email_newsletter      true or false
+
+Examples:
+  seedr settings set subtitles_language=en
+  seedr settings set email_newsletter=false email_announcements=false`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running settings set command...")
+		ctx := context.Background()
+
+		if len(args) == 0 {
+			fmt.Println("Please specify the settings to change as key=value.")
+			cmd.Help()
+			return
+		}
+
+		update, err := parseSettingsUpdate(args)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		if err := internal.Account.UpdateSettings(ctx, update); err != nil {
+			fmt.Printf("Error updating settings: %v\n", err)
+			return
+		}
+		fmt.Printf("Updated %d setting(s).\n", len(args))
+	},
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var keys []string
+		for _, key := range settingKeys() {
+			keys = append(keys, key+"=")
+		}
+		return keys, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	},
+}
+
 func init() {
 	RootCmd.AddCommand(settingsCmd)
+	settingsCmd.AddCommand(settingsGetCmd, settingsSetCmd)
+	addOutputFlag(settingsGetCmd, "text", "json")
 }
 
 // printSeedrSettings prints formatted account settings.
@@ -45,3 +146,69 @@ func printSeedrSettings(data *internal.SeedrUserSettings) {
 	fmt.Printf("Bandwidth Used: %s\n", bandwidthUsed)
 	fmt.Printf("Country: %s\n", data.Country)
 }
+
+// accountSettingValues returns the editable settings keyed by their API names.
+func accountSettingValues(s seedr.AccountSettings) map[string]string {
+	return map[string]string{
+		"allow_remote_access": strconv.FormatBool(s.AllowRemoteAccess),
+		"site_language":       s.SiteLanguage,
+		"subtitles_language":  s.SubtitlesLanguage,
+		"email_announcements": strconv.FormatBool(s.EmailAnnouncements),
+		"email_newsletter":    strconv.FormatBool(s.EmailNewsletter),
+	}
+}
+
+// settingKeys returns the editable setting names in alphabetical order.
+func settingKeys() []string {
+	var keys []string
+	for key := range accountSettingValues(seedr.AccountSettings{}) {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// parseSettingsUpdate turns key=value arguments into a settings update.
+func parseSettingsUpdate(args []string) (seedr.SettingsUpdate, error) {
+	var update seedr.SettingsUpdate
+	parseBool := func(key, value string) (*bool, error) {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value '%s' for %s, expected true or false", value, key)
+		}
+		return &b, nil
+	}
+
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return update, fmt.Errorf("invalid setting '%s', expected key=value", arg)
+		}
+		var err error
+		switch key {
+		case "allow_remote_access":
+			update.AllowRemoteAccess, err = parseBool(key, value)
+		case "site_language":
+			update.SiteLanguage = &value
+		case "subtitles_language":
+			update.SubtitlesLanguage = &value
+		case "email_announcements":
+			update.EmailAnnouncements, err = parseBool(key, value)
+		case "email_newsletter":
+			update.EmailNewsletter, err = parseBool(key, value)
+		default:
+			return update, fmt.Errorf("unknown setting '%s', expected one of: %s", key, strings.Join(settingKeys(), ", "))
+		}
+		if err != nil {
+			return update, err
+		}
+	}
+	return update, nil
+}
+
+func completeSettingKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return settingKeys(), cobra.ShellCompDirectiveNoFileComp
+}
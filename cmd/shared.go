@@ -1,33 +1,58 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
 
 	"seedr/internal"
 
+	"github.com/charmbracelet/x/term"
+	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 )
 
 // SeedrObject represents a file or folder from Seedr.cc
 type SeedrObject struct {
-	isDir bool
-	name  string
-	id    string
+	isDir      bool
+	name       string
+	id         string
+	itemType   string // "folder", "file" or "torrent", matching the API's item types
+	path       string // Absolute path from the account root, e.g. "/Movies/x.mkv"
+	parentID   string
+	size       int
+	lastUpdate *time.Time
+	hash       string // Infohash of the torrent the item came from, if the API reports it
+	progress   time.Duration // Saved playback position of a video
 }
 
 var allSeedrObjects map[string]SeedrObject // Global map to store all objects for quick lookup
 var objectNames []string                 // Global slice for auto-completion names
 
+// folderListings caches ListContents results by folder ID for the lifetime of a command.
+var folderListings = make(map[string]*internal.SeedrListContentsResult)
+
 // GetFolderContents recursively traverses Seedr folders and collects all files and subfolders.
 func GetFolderContents(ctx context.Context, currentFolder *internal.SeedrListContentsResult, collectedObjects *[]SeedrObject) {
+	parentPath := folderObjectPath(currentFolder)
+	parentID := fmt.Sprintf("%d", currentFolder.ID)
+	if parentPath == "/" {
+		parentID = "0" // The root is always addressed as "0", whatever ID the listing reports
+	}
+
 	// Process immediate subfolders of the current folder
 	for _, subfolder := range currentFolder.Folders {
 		// Add subfolder itself
-		*collectedObjects = append(*collectedObjects, SeedrObject{isDir: true, name: subfolder.Name, id: fmt.Sprintf("%d", subfolder.ID)})
+		*collectedObjects = append(*collectedObjects, newFolderObject(subfolder, parentPath, parentID))
 
 		// Recursively get contents of subfolder
-		subfolderData, err := internal.Account.ListContents(ctx, fmt.Sprintf("%d", subfolder.ID))
+		subfolderData, err := listFolder(ctx, fmt.Sprintf("%d", subfolder.ID))
 		if err != nil {
 			internal.Log.Debug("Error listing contents of folder %d (%s): %v", subfolder.ID, subfolder.Name, err)
 			continue
@@ -38,7 +63,7 @@ func GetFolderContents(ctx context.Context, currentFolder *internal.SeedrListCon
 	// Process immediate files in the current folder
 	for _, file := range currentFolder.Files {
 		// Use file.FolderFileID for files
-		*collectedObjects = append(*collectedObjects, SeedrObject{isDir: false, name: file.Name, id: fmt.Sprintf("%d", file.FolderFileID)})
+		*collectedObjects = append(*collectedObjects, newFileObject(file, parentPath, parentID))
 	}
 }
 
@@ -50,37 +75,19 @@ func FetchObjectDetails() ([]string, error) {
 	}
 
 	ctx := context.Background()
-	rootData, err := internal.Account.ListContents(ctx, "0") // Root folder has ID "0"
+	rootData, err := listFolder(ctx, "0") // Root folder has ID "0"
 	if err != nil {
 		return nil, fmt.Errorf("error listing root contents: %w", err)
 	}
 
 	var collectedObjects []SeedrObject
-
-	// Process immediate subfolders of the root
-	for _, subfolder := range rootData.Folders {
-		// Add subfolder itself
-		collectedObjects = append(collectedObjects, SeedrObject{isDir: true, name: subfolder.Name, id: fmt.Sprintf("%d", subfolder.ID)})
-		
-		// Recursively get contents of subfolder
-		subfolderData, err := internal.Account.ListContents(ctx, fmt.Sprintf("%d", subfolder.ID))
-		if err != nil {
-			internal.Log.Debug("Error listing contents of folder %d (%s): %v", subfolder.ID, subfolder.Name, err)
-			continue
-		}
-		GetFolderContents(ctx, subfolderData, &collectedObjects) // Recursively add sub-contents
-	}
-
-	// Process immediate files in the root
-	for _, file := range rootData.Files {
-		collectedObjects = append(collectedObjects, SeedrObject{isDir: false, name: file.Name, id: fmt.Sprintf("%d", file.FolderFileID)})
-	}
+	GetFolderContents(ctx, rootData, &collectedObjects)
 
 	allSeedrObjects = make(map[string]SeedrObject)
 	objectNames = make([]string, 0, len(collectedObjects))
 	for _, obj := range collectedObjects {
 		// If names are not unique, this map will only store the last encountered object for a given name.
-		// For a more robust solution, names could be disambiguated (e.g., by appending parent folder name).
+		// Absolute paths (see resolvePath) can be used to address a specific item unambiguously.
 		allSeedrObjects[obj.name] = obj
 		objectNames = append(objectNames, obj.name)
 	}
@@ -101,3 +108,374 @@ func CompleteSeedrObjectPrompt(cmd *cobra.Command, args []string, toComplete str
 
 	return names, cobra.ShellCompDirectiveNoFileComp
 }
+
+// newFolderObject builds a SeedrObject for a folder located under parentPath.
+func newFolderObject(f internal.SeedrFolder, parentPath, parentID string) SeedrObject {
+	return SeedrObject{
+		isDir:      true,
+		name:       f.Name,
+		id:         fmt.Sprintf("%d", f.ID),
+		itemType:   "folder",
+		path:       path.Join(parentPath, f.Name),
+		parentID:   parentID,
+		size:       f.Size,
+		lastUpdate: f.LastUpdate,
+		hash:       f.Hash,
+	}
+}
+
+// newFileObject builds a SeedrObject for a file located under parentPath.
+func newFileObject(f internal.SeedrFile, parentPath, parentID string) SeedrObject {
+	return SeedrObject{
+		isDir:      false,
+		name:       f.Name,
+		id:         fmt.Sprintf("%d", f.FolderFileID),
+		itemType:   "file",
+		path:       path.Join(parentPath, f.Name),
+		parentID:   parentID,
+		size:       f.Size,
+		lastUpdate: f.LastUpdate,
+		hash:       f.Hash,
+		progress:   f.Progress(),
+	}
+}
+
+// newTorrentObject builds a SeedrObject for an active torrent located under parentPath.
+func newTorrentObject(t internal.SeedrTorrent, parentPath, parentID string) SeedrObject {
+	return SeedrObject{
+		isDir:      false,
+		name:       t.Name,
+		id:         fmt.Sprintf("%d", t.ID),
+		itemType:   "torrent",
+		path:       path.Join(parentPath, t.Name),
+		parentID:   parentID,
+		size:       t.Size,
+		lastUpdate: t.LastUpdate,
+		hash:       t.Hash,
+	}
+}
+
+// rootObject returns the SeedrObject representing the account root.
+func rootObject() SeedrObject {
+	return SeedrObject{isDir: true, name: "/", id: "0", itemType: "folder", path: "/"}
+}
+
+// folderPaths records the absolute path of every folder listed through listFolder, keyed by folder ID.
+var folderPaths = map[string]string{"0": "/"}
+
+// folderObjectPath returns the absolute path of a listed folder, falling back to its full name.
+func folderObjectPath(folder *internal.SeedrListContentsResult) string {
+	if p, ok := folderPaths[fmt.Sprintf("%d", folder.ID)]; ok {
+		return p
+	}
+	if folder.Parent == nil {
+		return "/"
+	}
+	return "/" + strings.Trim(folder.Fullname, "/")
+}
+
+// listFolder lists a folder's contents, reusing a previous result from the same command when possible.
+func listFolder(ctx context.Context, folderID string) (*internal.SeedrListContentsResult, error) {
+	if cached, ok := folderListings[folderID]; ok {
+		return cached, nil
+	}
+	contents, err := internal.Account.ListContents(ctx, folderID)
+	if err != nil {
+		return nil, err
+	}
+	folderListings[folderID] = contents
+	if p, ok := folderPaths[folderID]; ok {
+		// The root listing reports the account's real root ID rather than "0"
+		folderPaths[fmt.Sprintf("%d", contents.ID)] = p
+	}
+
+	parentPath := folderObjectPath(contents)
+	for _, sub := range contents.Folders {
+		folderPaths[fmt.Sprintf("%d", sub.ID)] = path.Join(parentPath, sub.Name)
+	}
+	return contents, nil
+}
+
+// invalidateFolder drops cached listings after a folder's contents were changed.
+func invalidateFolder(folderID string) {
+	delete(folderListings, folderID)
+}
+
+// folderChildren returns the folders, files and torrents directly inside the given folder object.
+func folderChildren(ctx context.Context, folder SeedrObject) ([]SeedrObject, error) {
+	folderPaths[folder.id] = folder.path
+	contents, err := listFolder(ctx, folder.id)
+	if err != nil {
+		return nil, err
+	}
+
+	var children []SeedrObject
+	for _, f := range contents.Folders {
+		children = append(children, newFolderObject(f, folder.path, folder.id))
+	}
+	for _, f := range contents.Files {
+		children = append(children, newFileObject(f, folder.path, folder.id))
+	}
+	for _, t := range contents.Torrents {
+		children = append(children, newTorrentObject(t, folder.path, folder.id))
+	}
+	return children, nil
+}
+
+// splitRemotePath cleans an absolute remote path and returns its non-empty segments.
+func splitRemotePath(p string) []string {
+	cleaned := path.Clean("/" + p)
+	if cleaned == "/" {
+		return nil
+	}
+	return strings.Split(strings.TrimPrefix(cleaned, "/"), "/")
+}
+
+// resolvePath walks an absolute path such as "/Movies/x.mkv" from the account root.
+func resolvePath(ctx context.Context, p string) (SeedrObject, error) {
+	current := rootObject()
+	for _, segment := range splitRemotePath(p) {
+		if !current.isDir {
+			return SeedrObject{}, fmt.Errorf("'%s' is not a directory", current.path)
+		}
+		children, err := folderChildren(ctx, current)
+		if err != nil {
+			return SeedrObject{}, fmt.Errorf("error listing '%s': %w", current.path, err)
+		}
+		found := false
+		for _, child := range children {
+			if child.name == segment {
+				current = child
+				found = true
+				break
+			}
+		}
+		if !found {
+			return SeedrObject{}, fmt.Errorf("'%s' not found", path.Join(current.path, segment))
+		}
+	}
+	return current, nil
+}
+
+// hasGlobMeta reports whether a path contains shell-style wildcard characters.
+func hasGlobMeta(p string) bool {
+	return strings.ContainsAny(p, "*?[")
+}
+
+// globPath expands a shell-style pattern such as "/Downloads/*.nfo" segment by segment.
+func globPath(ctx context.Context, pattern string) ([]SeedrObject, error) {
+	matches := []SeedrObject{rootObject()}
+	for _, segment := range splitRemotePath(pattern) {
+		if _, err := path.Match(segment, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+		}
+		var next []SeedrObject
+		for _, parent := range matches {
+			if !parent.isDir {
+				continue
+			}
+			children, err := folderChildren(ctx, parent)
+			if err != nil {
+				return nil, fmt.Errorf("error listing '%s': %w", parent.path, err)
+			}
+			for _, child := range children {
+				if ok, _ := path.Match(segment, child.name); ok {
+					next = append(next, child)
+				}
+			}
+		}
+		matches = next
+	}
+	return matches, nil
+}
+
+// lookupObject resolves a command argument to a SeedrObject.
+// Absolute paths are walked from the root; bare names use the account-wide name lookup.
+func lookupObject(ctx context.Context, arg string) (SeedrObject, error) {
+	if strings.HasPrefix(arg, "/") {
+		return resolvePath(ctx, arg)
+	}
+
+	if _, err := FetchObjectDetails(); err != nil {
+		return SeedrObject{}, fmt.Errorf("error fetching Seedr objects for lookup: %w", err)
+	}
+	obj, ok := allSeedrObjects[arg]
+	if !ok {
+		return SeedrObject{}, fmt.Errorf("item '%s' not found in your Seedr account", arg)
+	}
+	return obj, nil
+}
+
+// expandArgs resolves every argument to SeedrObjects, expanding glob patterns.
+// Patterns that match nothing are reported as errors, like a shell with failglob.
+func expandArgs(ctx context.Context, args []string) ([]SeedrObject, error) {
+	var objects []SeedrObject
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		found, err := expandArg(ctx, arg)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range found {
+			key := obj.itemType + ":" + obj.id
+			if !seen[key] {
+				seen[key] = true
+				objects = append(objects, obj)
+			}
+		}
+	}
+	return objects, nil
+}
+
+// expandArg resolves a single argument, which may be a name, an absolute path or a pattern.
+func expandArg(ctx context.Context, arg string) ([]SeedrObject, error) {
+	obj, err := lookupObject(ctx, arg)
+	if !hasGlobMeta(arg) {
+		if err != nil {
+			return nil, err
+		}
+		return []SeedrObject{obj}, nil
+	}
+	// Names such as "Movie [1080p].mkv" contain wildcard characters; prefer an exact match.
+	if err == nil {
+		return []SeedrObject{obj}, nil
+	}
+	matches, err := globPath(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no matches for '%s'", arg)
+	}
+	return matches, nil
+}
+
+// confirm asks a yes/no question on stdin and reports whether the answer was yes.
+func confirm(prompt string) bool {
+	fmt.Printf("%s [y/N]: ", prompt)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// stdinReader is shared by prompts that read several lines from piped stdin.
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// readPassword prompts for a secret on stderr and reads it without echo when stdin is a terminal.
+// Otherwise it reads a single line from stdin, so secrets can be piped in.
+func readPassword(prompt string) (string, error) {
+	if stdinIsTerminal() {
+		fmt.Fprint(os.Stderr, prompt)
+		secret, err := term.ReadPassword(os.Stdin.Fd())
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("error reading password: %w", err)
+		}
+		return string(secret), nil
+	}
+	line, err := stdinReader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("error reading password from stdin: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// stdinIsTerminal reports whether stdin is attached to an interactive terminal.
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// parseSize parses a human-readable size such as "4GB", "700MiB" or "1500000".
+func parseSize(s string) (int, error) {
+	n, err := humanize.ParseBytes(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size '%s': %w", s, err)
+	}
+	return int(n), nil
+}
+
+// parseAge parses an age such as "14d", "2w" or "36h".
+// Days and weeks are accepted in addition to the units understood by time.ParseDuration.
+func parseAge(s string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if strings.HasSuffix(s, suffix) {
+			n, err := strconv.ParseFloat(strings.TrimSuffix(s, suffix), 64)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid age '%s'", s)
+			}
+			return time.Duration(n * float64(unit)), nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age '%s'", s)
+	}
+	return d, nil
+}
+
+// outputFormat is the value of the --output flag for commands that support it.
+var outputFormat string
+
+// outputFormatsAnnotation lists the formats a command's --output flag accepts.
+const outputFormatsAnnotation = "seedr_output_formats"
+
+// outputFlag is the value of an --output flag. It rejects formats the command does not support,
+// whether given on the command line or in the config file.
+type outputFlag struct {
+	formats []string
+}
+
+func (f outputFlag) String() string { return outputFormat }
+func (f outputFlag) Type() string   { return "string" }
+
+func (f outputFlag) Set(value string) error {
+	if !containsString(f.formats, value) {
+		return fmt.Errorf("expected one of %s", strings.Join(f.formats, ", "))
+	}
+	outputFormat = value
+	return nil
+}
+
+// addOutputFlag registers the --output flag on a command with the given allowed formats, the
+// first being the default.
+func addOutputFlag(c *cobra.Command, formats ...string) {
+	outputFormat = formats[0]
+	c.Flags().VarP(outputFlag{formats}, "output", "o", "Output format ("+strings.Join(formats, "|")+")")
+	c.Flags().SetAnnotation("output", outputFormatsAnnotation, formats)
+	c.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return formats, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// printJSON writes v to stdout as indented JSON.
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// readArgs returns args, or the non-empty lines of stdin when the only argument is "-".
+// This lets paths printed by other commands be piped in, e.g. `seedr search x -o paths | seedr rm - --yes`.
+func readArgs(args []string) ([]string, error) {
+	if len(args) != 1 || args[0] != "-" {
+		return args, nil
+	}
+	var lines []string
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading stdin: %w", err)
+	}
+	return lines, nil
+}
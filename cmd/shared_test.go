@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestOutputFlag(t *testing.T) {
+	old := outputFormat
+	t.Cleanup(func() { outputFormat = old })
+
+	tests := []struct {
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{nil, "text", false},
+		{[]string{"-o", "json"}, "json", false},
+		{[]string{"--output=paths"}, "paths", false},
+		{[]string{"-o", "jsn"}, "", true},
+		{[]string{"-o", "JSON"}, "", true},
+		{[]string{"--output="}, "", true},
+	}
+	for _, tt := range tests {
+		c := &cobra.Command{Use: "test"}
+		addOutputFlag(c, "text", "json", "paths")
+		err := c.ParseFlags(tt.args)
+		if tt.wantErr {
+			if err == nil || !strings.Contains(err.Error(), "text, json, paths") {
+				t.Errorf("%q: error = %v, want one listing the formats", tt.args, err)
+			}
+			continue
+		}
+		if err != nil || outputFormat != tt.want {
+			t.Errorf("%q: output = %q, %v; want %q", tt.args, outputFormat, err, tt.want)
+		}
+	}
+}
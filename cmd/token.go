@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"seedr/internal"
+	"seedr/pkg/seedr"
+
+	"github.com/spf13/cobra"
+)
+
+// tokenCmd represents the token command
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Export or import the saved token",
+	Long: `These commands move a login to another machine, e.g. to provision a headless
+box without going through the device flow there. The token is exchanged as a
+single Base64 string. Treat it like a password.
+
+Example:
+  seedr token export | ssh box seedr token import -`,
+	PersistentPreRunE: skipLogin,
+}
+
+// tokenExportCmd represents the token export command
+var tokenExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Print the saved token as Base64",
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running token export command...")
+
+		if err := internal.LoadSavedAccount(); err != nil {
+			if errors.Is(err, internal.ErrNotLoggedIn) {
+				fmt.Fprintln(os.Stderr, "Not logged in.")
+				return
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+		encoded, err := internal.Account.Token().ToBase64()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding token: %v\n", err)
+			return
+		}
+		if stdinIsTerminal() {
+			fmt.Fprintln(os.Stderr, "Warning: this token grants full access to your account; keep it secret.")
+		}
+		fmt.Println(encoded)
+	},
+}
+
+// tokenImportCmd represents the token import command
+var tokenImportCmd = &cobra.Command{
+	Use:   "import <base64-token|->",
+	Short: "Save a token exported on another machine",
+	Long: `This command saves a token printed by "seedr token export", replacing any saved
+token. Pass "-" to read it from stdin. The token is checked against Seedr before
+it is saved, unless --no-verify is given.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running token import command...")
+
+		if len(args) != 1 {
+			fmt.Println("Please specify the exported token, or - to read it from stdin.")
+			cmd.Help()
+			return
+		}
+
+		encoded := args[0]
+		if encoded == "-" {
+			data, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading stdin: %v\n", err)
+				return
+			}
+			encoded = string(data)
+		}
+		token, err := seedr.TokenFromBase64(strings.TrimSpace(encoded))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+		if token.GetAccessToken() == "" {
+			fmt.Fprintln(os.Stderr, "Error: the token has no access token.")
+			return
+		}
+
+		if !importNoVerify {
+			client := seedr.NewClient(token)
+			settings, err := client.GetSettings(context.Background())
+			client.Close()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: the token was rejected by Seedr: %v\n", err)
+				return
+			}
+			fmt.Printf("Token belongs to %s.\n", settings.Account.Username)
+		}
+
+		if err := internal.SaveToken(token); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return
+		}
+		fmt.Printf("Token saved: %s\n", token.String())
+	},
+}
+
+var importNoVerify bool
+
+func init() {
+	RootCmd.AddCommand(tokenCmd)
+	tokenCmd.AddCommand(tokenExportCmd, tokenImportCmd)
+	tokenImportCmd.Flags().BoolVar(&importNoVerify, "no-verify", false, "Save the token without checking it against Seedr")
+}
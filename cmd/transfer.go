@@ -0,0 +1,381 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"seedr/internal"
+	"seedr/pkg/seedr"
+
+	"github.com/spf13/cobra"
+)
+
+// transferCmd represents the transfer command
+var transferCmd = &cobra.Command{
+	Use:   "transfer <path>... --to <profile>",
+	Short: "Copy torrents to another account profile",
+	Long: `This command copies folders and torrents from one account profile to another
+without downloading them. A magnet link is rebuilt from each item's infohash and
+added on the destination account, which fetches the content itself; thanks to
+Seedr's cache this is often instant. The command then waits until the
+destination has the content. With --delete-source, each item is deleted from the
+source account once the destination has it.
+
+Only items that came from a torrent whose infohash Seedr reports can be
+transferred: active torrents, and folders whose files carry the infohash.
+
+Examples:
+  seedr transfer /Movies/Big.Buck.Bunny --to shared
+  seedr transfer "/Series/*" --from personal --to shared --delete-source
+  seedr transfer /Linux.iso --to shared --no-wait`,
+	PersistentPreRunE: skipLogin,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running transfer command...")
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		if len(args) == 0 {
+			fmt.Println("Please specify the items to transfer.")
+			cmd.Help()
+			return
+		}
+		if transferTo == "" {
+			fmt.Println("Please specify the destination profile with --to.")
+			return
+		}
+		from := transferFrom
+		if from == "" {
+			from = internal.ActiveProfile()
+		}
+		if from == transferTo {
+			fmt.Printf("Error: the source and destination are both profile '%s'.\n", from)
+			return
+		}
+		if transferDeleteSource && transferNoWait {
+			fmt.Println("Error: --delete-source needs to wait for the destination; it cannot be combined with --no-wait.")
+			return
+		}
+
+		if err := internal.SwitchProfile(from); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading source profile '%s': %v\n", from, err)
+			return
+		}
+		dest, err := internal.ProfileClient(transferTo)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading destination profile '%s': %v\n", transferTo, err)
+			return
+		}
+		defer dest.Close()
+
+		objects, err := expandArgs(ctx, args)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		var jobs []*transferJob
+		for _, obj := range objects {
+			job := &transferJob{obj: obj, title: obj.name}
+			job.info, job.err = transferTorrentInfo(ctx, obj)
+			if job.err != nil {
+				job.status = transferFailed
+			}
+			jobs = append(jobs, job)
+		}
+
+		startTransfers(ctx, dest, jobs)
+		if !transferNoWait {
+			waitForTransfers(ctx, dest, jobs)
+		}
+		if transferDeleteSource {
+			deleteTransferredSources(ctx, jobs)
+		}
+		printTransferSummary(from, transferTo, jobs)
+	},
+	ValidArgsFunction: CompleteSeedrObjectPrompt,
+}
+
+var (
+	transferFrom         string
+	transferTo           string
+	transferDeleteSource bool
+	transferNoWait       bool
+	transferTimeout      time.Duration
+	transferInterval     time.Duration
+)
+
+func init() {
+	RootCmd.AddCommand(transferCmd)
+	transferCmd.Flags().StringVar(&transferFrom, "from", "", "Source profile (default: the active profile)")
+	transferCmd.Flags().StringVar(&transferTo, "to", "", "Destination profile")
+	transferCmd.Flags().BoolVar(&transferDeleteSource, "delete-source", false, "Delete each item from the source once the destination has it")
+	transferCmd.Flags().BoolVar(&transferNoWait, "no-wait", false, "Return once the torrents are added instead of waiting for them to complete")
+	transferCmd.Flags().DurationVar(&transferTimeout, "timeout", 2*time.Hour, "How long to wait for the destination to complete")
+	transferCmd.Flags().DurationVar(&transferInterval, "interval", 15*time.Second, "How often to check the destination")
+	transferCmd.RegisterFlagCompletionFunc("from", completeProfileNames)
+	transferCmd.RegisterFlagCompletionFunc("to", completeProfileNames)
+}
+
+// transferStatus is the state of a single item being transferred.
+type transferStatus int
+
+const (
+	transferPending    transferStatus = iota // Added on the destination, not complete yet
+	transferDone                             // The destination has the content
+	transferWishlisted                       // Queued on the destination's wishlist for lack of space
+	transferFailed
+)
+
+// transferJob tracks one source item through the transfer.
+type transferJob struct {
+	obj      SeedrObject
+	info     *seedr.TorrentInfo
+	title    string // Name of the torrent on the destination
+	status   transferStatus
+	progress string // Last progress reported by the destination
+	added    bool   // Added on the destination by this run
+	byName   bool   // Found complete by name only, as the destination reported no infohash
+	deleted  bool   // Deleted from the source
+	err      error
+}
+
+// transferTorrentInfo finds the infohash an item came from and builds the torrent to add from it.
+func transferTorrentInfo(ctx context.Context, obj SeedrObject) (*seedr.TorrentInfo, error) {
+	hash := obj.hash
+	if hash == "" && obj.isDir {
+		var err error
+		if hash, err = folderTorrentHash(ctx, obj); err != nil {
+			return nil, err
+		}
+	}
+	if hash == "" {
+		return nil, fmt.Errorf("Seedr reports no infohash for '%s'", obj.path)
+	}
+	return seedr.TorrentInfoFromHash(hash, obj.name)
+}
+
+// folderTorrentHash returns the infohash shared by every file in a folder, looking into subfolders.
+// Folders holding files from several torrents have no single infohash and are rejected.
+func folderTorrentHash(ctx context.Context, folder SeedrObject) (string, error) {
+	hashes := make(map[string]bool)
+	var walk func(dir SeedrObject) error
+	walk = func(dir SeedrObject) error {
+		children, err := folderChildren(ctx, dir)
+		if err != nil {
+			return fmt.Errorf("error listing '%s': %w", dir.path, err)
+		}
+		for _, child := range children {
+			if child.hash != "" {
+				hashes[strings.ToLower(child.hash)] = true
+			} else if child.isDir {
+				if err := walk(child); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := walk(folder); err != nil {
+		return "", err
+	}
+	switch len(hashes) {
+	case 0:
+		return "", fmt.Errorf("Seedr reports no infohash for '%s'", folder.path)
+	case 1:
+		for hash := range hashes {
+			return hash, nil
+		}
+	}
+	return "", fmt.Errorf("'%s' holds files from %d torrents; transfer its subfolders instead", folder.path, len(hashes))
+}
+
+// startTransfers adds every pending job's magnet link on the destination, skipping items it already has.
+func startTransfers(ctx context.Context, dest *seedr.Client, jobs []*transferJob) {
+	root, err := dest.ListContents(ctx, "0")
+	if err != nil {
+		for _, job := range jobs {
+			if job.status == transferPending {
+				job.status, job.err = transferFailed, fmt.Errorf("error listing destination: %w", err)
+			}
+		}
+		return
+	}
+
+	for _, job := range jobs {
+		if job.status != transferPending {
+			continue
+		}
+		if status, progress, found := transferState(root, job); found {
+			job.status, job.progress = status, progress
+			fmt.Printf("'%s' is already on the destination.\n", job.obj.path)
+			continue
+		}
+
+		magnet := job.info.Magnet()
+		fmt.Printf("Adding '%s' on the destination...\n", job.obj.path)
+		result, err := dest.AddTorrent(ctx, &magnet, nil, nil, "-1")
+		switch {
+		case err != nil && isWishlistedError(err):
+			job.status = transferWishlisted
+		case err != nil:
+			job.status, job.err = transferFailed, fmt.Errorf("error adding torrent: %w", err)
+		case !result.Result:
+			job.status, job.err = transferFailed, fmt.Errorf("the API did not accept the torrent")
+		default:
+			job.added = true
+			if result.Title != "" {
+				job.title = result.Title
+			}
+		}
+	}
+}
+
+// waitForTransfers polls the destination until every pending job is complete, the timeout expires or
+// the context is cancelled.
+func waitForTransfers(ctx context.Context, dest *seedr.Client, jobs []*transferJob) {
+	deadline := time.Now().Add(transferTimeout)
+	for {
+		pending := 0
+		for _, job := range jobs {
+			if job.status == transferPending {
+				pending++
+			}
+		}
+		if pending == 0 {
+			return
+		}
+
+		root, err := dest.ListContents(ctx, "0")
+		if err != nil && !errors.Is(err, context.Canceled) {
+			fmt.Fprintf(os.Stderr, "Error listing destination: %v\n", err)
+		}
+		if err == nil {
+			for _, job := range jobs {
+				if job.status != transferPending {
+					continue
+				}
+				status, progress, found := transferState(root, job)
+				if !found {
+					continue
+				}
+				if status == transferDone {
+					job.status = transferDone
+					job.byName = !transferHashMatch(root, job)
+					fmt.Printf("'%s' is complete on the destination.\n", job.title)
+				} else if progress != job.progress {
+					job.progress = progress
+					fmt.Printf("'%s': %s%%\n", job.title, progress)
+				}
+			}
+		}
+
+		if time.Now().After(deadline) {
+			for _, job := range jobs {
+				if job.status == transferPending {
+					job.status, job.err = transferFailed, fmt.Errorf("not complete on the destination after %s", transferTimeout)
+				}
+			}
+			return
+		}
+		select {
+		case <-ctx.Done():
+			for _, job := range jobs {
+				if job.status == transferPending {
+					job.err = fmt.Errorf("stopped waiting; the destination keeps downloading")
+				}
+			}
+			return
+		case <-time.After(transferInterval):
+		}
+	}
+}
+
+// transferState looks for a job in the destination's root listing. A matching active torrent means
+// the transfer is in progress; a folder with the torrent's infohash means it is complete. Once this
+// run has added the torrent, a folder with its name and no infohash also counts as complete, since
+// the API does not always report one; such a match never lets the source be deleted.
+func transferState(root *seedr.ListContentsResult, job *transferJob) (transferStatus, string, bool) {
+	for _, t := range root.Torrents {
+		if t.Hash != "" && job.info.MatchesHash(t.Hash) {
+			return transferPending, t.Progress, true
+		}
+	}
+	if transferHashMatch(root, job) {
+		return transferDone, "100", true
+	}
+	if job.added {
+		for _, f := range root.Folders {
+			if f.Hash == "" && f.Name == job.title {
+				return transferDone, "100", true
+			}
+		}
+	}
+	return transferPending, "", false
+}
+
+// transferHashMatch reports whether the destination has a folder with the job's infohash.
+func transferHashMatch(root *seedr.ListContentsResult, job *transferJob) bool {
+	for _, f := range root.Folders {
+		if f.Hash != "" && job.info.MatchesHash(f.Hash) {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteTransferredSources deletes the source of every job the destination has completed.
+// Nothing is deleted once the command has been interrupted.
+func deleteTransferredSources(ctx context.Context, jobs []*transferJob) {
+	if ctx.Err() != nil {
+		return
+	}
+	for _, job := range jobs {
+		if job.status != transferDone {
+			continue
+		}
+		if job.byName {
+			job.err = fmt.Errorf("the destination has a folder of the same name but reports no infohash; not deleting the source")
+			continue
+		}
+		ref := seedr.ItemRef{Type: job.obj.itemType, ID: job.obj.id}
+		if _, err := internal.Account.DeleteItems(ctx, []seedr.ItemRef{ref}); err != nil {
+			job.err = fmt.Errorf("complete on the destination, but deleting the source failed: %w", err)
+			continue
+		}
+		job.deleted = true
+		invalidateFolder(job.obj.parentID)
+	}
+}
+
+// printTransferSummary prints the outcome of every item, with the reason for any failure.
+func printTransferSummary(from, to string, jobs []*transferJob) {
+	fmt.Printf("\nTransfer from '%s' to '%s':\n", from, to)
+	for _, job := range jobs {
+		var state string
+		switch job.status {
+		case transferDone:
+			state = "done"
+			if job.deleted {
+				state = "done, source deleted"
+			}
+		case transferPending:
+			state = "added"
+			if job.progress != "" {
+				state = fmt.Sprintf("added, %s%%", job.progress)
+			}
+		case transferWishlisted:
+			state = "queued on the destination's wishlist (not enough space)"
+		case transferFailed:
+			state = "failed"
+		}
+		if job.err != nil {
+			state += ": " + job.err.Error()
+		}
+		fmt.Printf("  %s: %s\n", job.obj.path, state)
+	}
+}
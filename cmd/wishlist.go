@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"seedr/internal"
+	"seedr/pkg/seedr"
+
+	"github.com/spf13/cobra"
+)
+
+// wishlistCmd represents the wishlist command
+var wishlistCmd = &cobra.Command{
+	Use:     "wishlist",
+	Aliases: []string{"wl"},
+	Short:   "Manage torrents queued on the wishlist",
+	Long: `Torrents that do not fit in your storage are queued on the wishlist.
+These commands list them, remove them, or add them to your account once there
+is enough free space. Without a subcommand, the wishlist is listed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		wishlistListCmd.Run(cmd, args)
+	},
+}
+
+// wishlistListCmd represents the wishlist list command
+var wishlistListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List wishlist items",
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running wishlist list command...")
+		ctx := context.Background()
+
+		items, err := internal.Account.GetWishlist(ctx)
+		if err != nil {
+			fmt.Printf("Error getting wishlist: %v\n", err)
+			return
+		}
+
+		if outputFormat == "json" {
+			if items == nil {
+				items = []seedr.WishlistItem{}
+			}
+			if err := printJSON(items); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding wishlist: %v\n", err)
+			}
+			return
+		}
+		if len(items) == 0 {
+			fmt.Println("The wishlist is empty.")
+			return
+		}
+		printWishlist(items)
+	},
+}
+
+// wishlistRmCmd represents the wishlist rm command
+var wishlistRmCmd = &cobra.Command{
+	Use:   "rm <id>...",
+	Short: "Remove items from the wishlist",
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running wishlist rm command...")
+		ctx := context.Background()
+
+		if len(args) == 0 {
+			fmt.Println("Please specify the IDs of the wishlist items to remove.")
+			cmd.Help()
+			return
+		}
+		for _, id := range args {
+			if _, err := internal.Account.DeleteWishlist(ctx, id); err != nil {
+				fmt.Printf("Error removing wishlist item %s: %v\n", id, err)
+				continue
+			}
+			fmt.Printf("Removed wishlist item %s.\n", id)
+		}
+	},
+	ValidArgsFunction: completeWishlistIDs,
+}
+
+// wishlistPromoteCmd represents the wishlist promote command
+var wishlistPromoteCmd = &cobra.Command{
+	Use:   "promote <id>...",
+	Short: "Add wishlist items to the account once they fit",
+	Long: `This command adds wishlist items to your account. An item is only submitted
+when its size fits in the free space; otherwise it is skipped, or with --wait
+the command keeps checking every --interval until enough space frees up.
+
+Examples:
+  seedr wishlist promote 1234
+  seedr wishlist promote --all --wait --interval 5m`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running wishlist promote command...")
+		ctx := context.Background()
+
+		if len(args) == 0 && !promoteAll {
+			fmt.Println("Please specify the IDs of the wishlist items to promote, or --all.")
+			cmd.Help()
+			return
+		}
+
+		folderID, ok := resolveTargetFolder()
+		if !ok {
+			return
+		}
+
+		items, err := internal.Account.GetWishlist(ctx)
+		if err != nil {
+			fmt.Printf("Error getting wishlist: %v\n", err)
+			return
+		}
+		targets, err := selectWishlistItems(items, args, promoteAll)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		for _, item := range targets {
+			for {
+				done, err := promoteWishlistItem(ctx, item, folderID)
+				if err != nil {
+					fmt.Printf("Error promoting '%s': %v\n", item.Title, err)
+					break
+				}
+				if done || !promoteWait {
+					break
+				}
+				internal.Log.Debug("Waiting %s before retrying '%s'", promoteInterval, item.Title)
+				time.Sleep(promoteInterval)
+			}
+		}
+	},
+	ValidArgsFunction: completeWishlistIDs,
+}
+
+var (
+	promoteAll      bool
+	promoteWait     bool
+	promoteInterval time.Duration
+)
+
+func init() {
+	RootCmd.AddCommand(wishlistCmd)
+	wishlistCmd.AddCommand(wishlistListCmd, wishlistRmCmd, wishlistPromoteCmd)
+	addOutputFlag(wishlistListCmd, "text", "json")
+
+	wishlistPromoteCmd.Flags().BoolVar(&promoteAll, "all", false, "Promote every wishlist item")
+	wishlistPromoteCmd.Flags().BoolVar(&promoteWait, "wait", false, "Keep retrying until each item fits")
+	wishlistPromoteCmd.Flags().DurationVar(&promoteInterval, "interval", time.Minute, "How often to check the free space with --wait")
+	wishlistPromoteCmd.Flags().StringVarP(&targetDirectoryName, "target-directory", "t", "", "Name of the target directory in Seedr (optional)")
+	wishlistPromoteCmd.RegisterFlagCompletionFunc("target-directory", completeFolderPrompt)
+}
+
+// printWishlist prints wishlist items as a table.
+func printWishlist(items []seedr.WishlistItem) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSIZE\tADDED\tTITLE")
+	for _, item := range items {
+		added := "N/A"
+		if item.Added != nil {
+			added = item.Added.Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", item.ID, internal.HumanReadableBytes(item.Size), added, item.Title)
+	}
+	w.Flush()
+}
+
+// selectWishlistItems returns the wishlist items with the given IDs, in the order given, or all of them.
+func selectWishlistItems(items []seedr.WishlistItem, ids []string, all bool) ([]seedr.WishlistItem, error) {
+	if all {
+		return items, nil
+	}
+	byID := make(map[int]seedr.WishlistItem, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+	var selected []seedr.WishlistItem
+	for _, arg := range ids {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid wishlist ID '%s'", arg)
+		}
+		item, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("wishlist item %d not found", id)
+		}
+		selected = append(selected, item)
+	}
+	return selected, nil
+}
+
+// promoteWishlistItem submits a wishlist item if it fits in the free space.
+// It reports false without an error when the item does not fit yet.
+func promoteWishlistItem(ctx context.Context, item seedr.WishlistItem, folderID string) (bool, error) {
+	mb, err := internal.Account.GetMemoryBandwidth(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error fetching free space: %w", err)
+	}
+	if free := mb.SpaceMax - mb.SpaceUsed; item.Size > free {
+		fmt.Printf("'%s' needs %s but only %s is free.\n", item.Title, internal.HumanReadableBytes(item.Size), internal.HumanReadableBytes(max(free, 0)))
+		return false, nil
+	}
+
+	if err := submitWishlistItem(ctx, item, folderID); err != nil {
+		if errors.Is(err, errStillWishlisted) {
+			fmt.Printf("'%s' still does not fit.\n", item.Title)
+			return false, nil
+		}
+		return false, err
+	}
+	fmt.Printf("Added '%s' from the wishlist.\n", item.Title)
+	return true, nil
+}
+
+// errStillWishlisted reports that Seedr kept an item on the wishlist because it still does not fit.
+var errStillWishlisted = errors.New("not enough space, the item stays on the wishlist")
+
+// submitWishlistItem adds a wishlist item to the account through AddTorrent with its wishlist ID.
+func submitWishlistItem(ctx context.Context, item seedr.WishlistItem, folderID string) error {
+	var magnet *string
+	if item.Magnet != "" {
+		magnet = &item.Magnet
+	}
+	wishlistID := strconv.Itoa(item.ID)
+	result, err := internal.Account.AddTorrent(ctx, magnet, nil, &wishlistID, folderID)
+	if err != nil {
+		if isWishlistedError(err) {
+			return errStillWishlisted
+		}
+		return err
+	}
+	if !result.Result {
+		return fmt.Errorf("the API did not accept the torrent")
+	}
+	return nil
+}
+
+// completeWishlistIDs completes wishlist item IDs, showing their titles as descriptions.
+func completeWishlistIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	items, err := internal.Account.GetWishlist(context.Background())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var ids []string
+	for _, item := range items {
+		ids = append(ids, fmt.Sprintf("%d\t%s", item.ID, item.Title))
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
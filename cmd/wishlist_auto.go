@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"regexp"
+	"sort"
+	"syscall"
+	"time"
+
+	"seedr/internal"
+	"seedr/pkg/seedr"
+
+	"github.com/spf13/cobra"
+)
+
+// wishlistAutoCmd represents the wishlist auto command
+var wishlistAutoCmd = &cobra.Command{
+	Use:   "auto",
+	Short: "Promote wishlist items automatically as space frees up",
+	Long: `This command checks the free space on your account and adds wishlist items
+that fit. It runs in the foreground, checking every --interval, until
+interrupted. Use --once to check a single time, e.g. from cron.
+
+Items are promoted in the order they were added to the wishlist (--order fifo).
+With --order priority, items matching the first --priority pattern go first,
+then those matching the second, and so on; remaining items follow in FIFO
+order. By default an item that does not fit blocks the ones behind it; use
+--fill to skip it and promote smaller items instead.
+
+Every promotion is appended to a local log (see --log).
+
+Examples:
+  seedr wishlist auto --interval 10m
+  seedr wishlist auto --once --fill
+  seedr wishlist auto --order priority --priority '(?i)2160p' --priority S01`,
+	Run: func(cmd *cobra.Command, args []string) {
+		internal.Log.Debug("Running wishlist auto command...")
+
+		order, err := newWishlistOrder(autoOrder, autoPriority)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		folderID, ok := resolveTargetFolder()
+		if !ok {
+			return
+		}
+		logPath := autoLogPath
+		if logPath == "" {
+			if logPath, err = internal.WishlistLogPath(); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				return
+			}
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		for {
+			if err := autoPromotePass(ctx, order, folderID, logPath); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				if autoOnce {
+					return
+				}
+			}
+			if autoOnce {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(autoInterval):
+			}
+		}
+	},
+}
+
+var (
+	autoOnce     bool
+	autoInterval time.Duration
+	autoOrder    string
+	autoPriority []string
+	autoFill     bool
+	autoLogPath  string
+)
+
+func init() {
+	wishlistCmd.AddCommand(wishlistAutoCmd)
+	wishlistAutoCmd.Flags().BoolVar(&autoOnce, "once", false, "Check once and exit instead of looping")
+	wishlistAutoCmd.Flags().DurationVar(&autoInterval, "interval", 5*time.Minute, "How often to check the free space")
+	wishlistAutoCmd.Flags().StringVar(&autoOrder, "order", "fifo", "Promotion order (fifo or priority)")
+	wishlistAutoCmd.Flags().StringArrayVar(&autoPriority, "priority", nil, "Regex for titles to promote first with --order priority (repeatable, highest first)")
+	wishlistAutoCmd.Flags().BoolVar(&autoFill, "fill", false, "Skip items that do not fit and promote smaller ones behind them")
+	wishlistAutoCmd.Flags().StringVar(&autoLogPath, "log", "", "File to record promotions in (default: wishlist.log in the seedr directory)")
+	wishlistAutoCmd.Flags().StringVarP(&targetDirectoryName, "target-directory", "t", "", "Name of the target directory in Seedr (optional)")
+	wishlistAutoCmd.RegisterFlagCompletionFunc("target-directory", completeFolderPrompt)
+	wishlistAutoCmd.RegisterFlagCompletionFunc("order", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"fifo", "priority"}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// wishlistOrder ranks wishlist items for automatic promotion.
+type wishlistOrder struct {
+	priority []*regexp.Regexp // Empty for plain FIFO
+}
+
+// newWishlistOrder parses the --order and --priority flags.
+func newWishlistOrder(order string, patterns []string) (wishlistOrder, error) {
+	var o wishlistOrder
+	switch order {
+	case "fifo":
+		if len(patterns) > 0 {
+			return o, fmt.Errorf("--priority requires --order priority")
+		}
+	case "priority":
+		if len(patterns) == 0 {
+			return o, fmt.Errorf("--order priority requires at least one --priority pattern")
+		}
+		for _, p := range patterns {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return o, fmt.Errorf("invalid --priority pattern '%s': %w", p, err)
+			}
+			o.priority = append(o.priority, re)
+		}
+	default:
+		return o, fmt.Errorf("invalid --order '%s', expected fifo or priority", order)
+	}
+	return o, nil
+}
+
+// rank returns the index of the first priority pattern matching title, or len(priority) if none match.
+func (o wishlistOrder) rank(title string) int {
+	for i, re := range o.priority {
+		if re.MatchString(title) {
+			return i
+		}
+	}
+	return len(o.priority)
+}
+
+// sort orders items by priority rank, then by the time they were added (oldest first), then by ID.
+func (o wishlistOrder) sort(items []seedr.WishlistItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if ri, rj := o.rank(items[i].Title), o.rank(items[j].Title); ri != rj {
+			return ri < rj
+		}
+		a, b := items[i].Added, items[j].Added
+		if a != nil && b != nil && !a.Equal(*b) {
+			return a.Before(*b)
+		}
+		return items[i].ID < items[j].ID
+	})
+}
+
+// autoPromotePass promotes every wishlist item that fits in the current free space.
+func autoPromotePass(ctx context.Context, order wishlistOrder, folderID, logPath string) error {
+	items, err := internal.Account.GetWishlist(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting wishlist: %w", err)
+	}
+	if len(items) == 0 {
+		internal.Log.Debug("Wishlist is empty, nothing to promote")
+		return nil
+	}
+	mb, err := internal.Account.GetMemoryBandwidth(ctx)
+	if err != nil {
+		return fmt.Errorf("error fetching free space: %w", err)
+	}
+	free := mb.SpaceMax - mb.SpaceUsed
+	internal.Log.Debug("%d wishlist item(s), %s free", len(items), internal.HumanReadableBytes(max(free, 0)))
+
+	order.sort(items)
+	for _, item := range items {
+		if item.Size > free {
+			if autoFill {
+				continue
+			}
+			internal.Log.Debug("'%s' needs %s, waiting for space", item.Title, internal.HumanReadableBytes(item.Size))
+			return nil
+		}
+		err := submitWishlistItem(ctx, item, folderID)
+		if errors.Is(err, errStillWishlisted) {
+			return nil // Seedr disagrees about the free space; try again next pass
+		}
+		if err != nil {
+			fmt.Printf("Error promoting '%s': %v\n", item.Title, err)
+			continue
+		}
+		free -= item.Size
+		fmt.Printf("%s Added '%s' (%s) from the wishlist.\n", time.Now().Format("2006-01-02 15:04:05"), item.Title, internal.HumanReadableBytes(item.Size))
+		if err := appendWishlistLog(logPath, item); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", logPath, err)
+		}
+	}
+	return nil
+}
+
+// appendWishlistLog records a promoted item as a tab-separated line: time, ID, size in bytes, title.
+func appendWishlistLog(logPath string, item seedr.WishlistItem) error {
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = fmt.Fprintf(f, "%s\t%d\t%d\t%s\n", time.Now().Format(time.RFC3339), item.ID, item.Size, item.Title)
+	return err
+}
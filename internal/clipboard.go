@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// CopyToClipboard copies the given text to the system clipboard. It uses the configured clipboard
+// command if there is one; otherwise it tries wl-copy (Wayland), xclip and xsel (X11) or pbcopy
+// (macOS), and finally the OSC 52 escape sequence, which asks the terminal to set the clipboard
+// and so also works over SSH.
+func CopyToClipboard(text string) error {
+	if fields := strings.Fields(Conf.Clipboard); len(fields) > 0 {
+		if err := runClipboardCommand(fields, text); err != nil {
+			return fmt.Errorf("failed to copy to clipboard with %s: %w", fields[0], err)
+		}
+		return nil
+	}
+
+	var candidates [][]string
+	if runtime.GOOS == "darwin" {
+		candidates = append(candidates, []string{"pbcopy"})
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		candidates = append(candidates, []string{"wl-copy"})
+	}
+	if os.Getenv("DISPLAY") != "" {
+		candidates = append(candidates, []string{"xclip", "-selection", "clipboard"}, []string{"xsel", "--clipboard", "--input"})
+	}
+	for _, args := range candidates {
+		if _, err := exec.LookPath(args[0]); err != nil {
+			continue
+		}
+		if err := runClipboardCommand(args, text); err != nil {
+			DebugLog("Copying with %s failed: %v", args[0], err)
+			continue
+		}
+		return nil
+	}
+
+	if err := copyWithOSC52(text); err != nil {
+		return fmt.Errorf("failed to copy to clipboard: no clipboard tool worked and OSC 52 failed: %w", err)
+	}
+	return nil
+}
+
+func runClipboardCommand(args []string, text string) error {
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader([]byte(text))
+	return cmd.Run()
+}
+
+// copyWithOSC52 writes the OSC 52 sequence to the controlling terminal. Inside tmux or screen the
+// sequence is wrapped so that it is passed through to the outer terminal.
+func copyWithOSC52(text string) error {
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer tty.Close()
+
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		seq = "\x1bP" + seq + "\x1b\\"
+	}
+	_, err = tty.WriteString(seq)
+	return err
+}
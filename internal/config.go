@@ -0,0 +1,572 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds the settings read from the config files and the environment.
+// Typed fields cover the settings used across commands and the TUI; per-command
+// flag defaults, such as "add.jobs", are looked up by name with Lookup.
+type Config struct {
+	DownloadDir     string        // Where downloads are saved; empty for the current directory
+	DownloadTimeout time.Duration // Timeout for fetching download URLs and .torrent files
+	RequestTimeout  time.Duration // Timeout for API requests made by the TUI
+	Player          string        // Command used to play media, e.g. "mpv"
+	Clipboard       string        // Command that copies stdin to the clipboard; empty to detect one
+	Output          string        // Default --output format for commands that support it
+
+	values map[string]ConfigValue
+}
+
+// ConfigValue is a raw setting together with where it came from.
+type ConfigValue struct {
+	Values []string // More than one for arrays
+	Source string   // The file or environment variable that set it
+}
+
+// String returns the value as it is passed to a flag or shown to the user.
+func (v ConfigValue) String() string {
+	return strings.Join(v.Values, ",")
+}
+
+// ConfigOption describes a setting with a typed field in Config.
+type ConfigOption struct {
+	Key     string
+	Default string
+	Help    string
+	apply   func(c *Config, value string) error
+}
+
+// ConfigOptions lists the settings with typed fields, in the order they are documented.
+// Top-level keys come first, as TOML requires them before any section.
+var ConfigOptions = []ConfigOption{
+	{"output", "", "Default output format (text, json, ...) for commands with --output", func(c *Config, v string) error {
+		c.Output = v
+		return nil
+	}},
+	{"download.dir", "", "Directory downloads are saved to; empty for the current directory", func(c *Config, v string) error {
+		c.DownloadDir = expandHome(v)
+		return nil
+	}},
+	{"download.timeout", "30s", "Timeout for fetching a download URL or a .torrent file", func(c *Config, v string) error {
+		return parseConfigDuration(v, &c.DownloadTimeout)
+	}},
+	{"request.timeout", "5s", "Timeout for API requests made by the TUI", func(c *Config, v string) error {
+		return parseConfigDuration(v, &c.RequestTimeout)
+	}},
+	{"player.command", "mpv", "Command used to play media; the URL is appended unless {url} is given", func(c *Config, v string) error {
+		if strings.TrimSpace(v) == "" {
+			return fmt.Errorf("must not be empty")
+		}
+		c.Player = v
+		return nil
+	}},
+	{"clipboard.command", "", "Command that copies stdin to the clipboard; empty to detect one", func(c *Config, v string) error {
+		c.Clipboard = v
+		return nil
+	}},
+}
+
+// Conf is the loaded configuration. It holds the defaults until LoadConfig is called.
+var Conf = DefaultConfig()
+
+// DefaultConfig returns the configuration used when no setting is given.
+func DefaultConfig() *Config {
+	c := &Config{values: make(map[string]ConfigValue)}
+	for _, opt := range ConfigOptions {
+		opt.apply(c, opt.Default)
+	}
+	return c
+}
+
+// FindConfigOption returns the typed option with the given key.
+func FindConfigOption(key string) (ConfigOption, bool) {
+	for _, opt := range ConfigOptions {
+		if opt.Key == key {
+			return opt, true
+		}
+	}
+	return ConfigOption{}, false
+}
+
+// Lookup returns the value of a setting from the environment or the config files.
+func (c *Config) Lookup(key string) (ConfigValue, bool) {
+	env := ConfigEnvVar(key)
+	if v, ok := os.LookupEnv(env); ok {
+		return ConfigValue{Values: []string{v}, Source: "$" + env}, true
+	}
+	v, ok := c.values[key]
+	return v, ok
+}
+
+// Keys returns the names of all settings given in the config files, sorted.
+func (c *Config) Keys() []string {
+	keys := make([]string, 0, len(c.values))
+	for key := range c.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Table returns the settings of a table such as "handlers", keyed by their name within it.
+func (c *Config) Table(section string) map[string]string {
+	table := make(map[string]string)
+	prefix := section + "."
+	for key := range c.values {
+		if name, ok := strings.CutPrefix(key, prefix); ok && !strings.Contains(name, ".") {
+			v, _ := c.Lookup(key)
+			table[name] = v.String()
+		}
+	}
+	return table
+}
+
+// ConfigTables lists the tables whose keys are chosen by the user, such as handler names.
+var ConfigTables = []string{"handlers", "filetypes"}
+
+// ConfigEnvVar returns the environment variable overriding a setting, e.g. SEEDR_DOWNLOAD_DIR for download.dir.
+func ConfigEnvVar(key string) string {
+	return "SEEDR_" + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// LoadConfig reads the main config file and the active profile's config file, which takes
+// precedence, then applies environment overrides. Conf is replaced even on error, so that a
+// broken file leaves the valid settings in effect.
+func LoadConfig() error {
+	c := DefaultConfig()
+	defer func() { Conf = c }()
+
+	paths, err := configPaths()
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error reading config file: %w", err)
+		}
+		values, err := parseConfig(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		for key, v := range values {
+			c.values[key] = ConfigValue{Values: v, Source: path}
+		}
+	}
+
+	for _, opt := range ConfigOptions {
+		v, ok := c.Lookup(opt.Key)
+		if !ok {
+			continue
+		}
+		if err := opt.apply(c, v.String()); err != nil {
+			return fmt.Errorf("invalid %s in %s: %w", opt.Key, v.Source, err)
+		}
+	}
+	return nil
+}
+
+// ValidateConfigOption checks a value for a typed setting without applying it.
+func ValidateConfigOption(opt ConfigOption, value string) error {
+	return opt.apply(DefaultConfig(), value)
+}
+
+// ConfigPath returns the config file of the active profile, which `seedr config set` writes to.
+func ConfigPath() (string, error) {
+	paths, err := configPaths()
+	if err != nil {
+		return "", err
+	}
+	return paths[len(paths)-1], nil
+}
+
+// configPaths returns the config files to read, in increasing order of precedence.
+func configPaths() ([]string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{filepath.Join(dir, "config.toml")}
+	if name := ActiveProfile(); name != DefaultProfile {
+		if err := ValidateProfileName(name); err != nil {
+			return nil, err
+		}
+		paths = append(paths, filepath.Join(dir, "profiles", name, "config.toml"))
+	}
+	return paths, nil
+}
+
+// SetConfigValue sets key to value in the config file at path, creating the file if needed.
+// The rest of the file, including comments, is kept as it is.
+func SetConfigValue(path, key, value string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading config file: %w", err)
+	}
+	if _, err := parseConfig(data); err != nil {
+		return fmt.Errorf("%s: %w; fix it with `seedr config edit`", path, err)
+	}
+
+	section, name := "", key
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		section, name = key[:i], key[i+1:]
+	}
+	line := formatConfigKey(name) + " = " + formatConfigValue(value)
+
+	var lines []string
+	if len(data) > 0 {
+		lines = strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	}
+	// insertAt is where a new key goes: after the section's last key, or right after its header.
+	// Top-level keys belong before the first header.
+	current, insertAt, replaced := "", -1, false
+	if section == "" {
+		insertAt = len(lines)
+		for i, l := range lines {
+			if strings.HasPrefix(strings.TrimSpace(l), "[") {
+				insertAt = i
+				break
+			}
+		}
+	}
+	for i, l := range lines {
+		trimmed := strings.TrimSpace(l)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "[") {
+			header := strings.TrimSpace(stripConfigComment(trimmed))
+			current, _ = parseConfigKey(header[1 : len(header)-1])
+			if current == section && insertAt < 0 {
+				insertAt = i + 1
+			}
+			continue
+		}
+		// The key may be dotted, as in "auto.interval" under [wishlist]
+		setting, _, _ := strings.Cut(stripConfigComment(l), "=")
+		k, _ := parseConfigKey(setting)
+		if current != "" {
+			k = current + "." + k
+		}
+		if current == section {
+			insertAt = i + 1
+		}
+		if k == key {
+			// Keep the key as written and any comment after the value
+			lines[i] = setting + "= " + formatConfigValue(value)
+			if comment := l[len(stripConfigComment(l)):]; comment != "" {
+				lines[i] += " " + comment
+			}
+			replaced = true
+			break
+		}
+	}
+	switch {
+	case replaced:
+	case insertAt >= 0:
+		lines = append(lines[:insertAt], append([]string{line}, lines[insertAt:]...)...)
+	default:
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "["+formatConfigSection(section)+"]", line)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("could not create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("error writing config file: %w", err)
+	}
+	return nil
+}
+
+// ConfigTemplate is written by `seedr config edit` when there is no config file yet.
+func ConfigTemplate() string {
+	var b strings.Builder
+	b.WriteString("# seedr configuration. Every setting can be overridden with an environment\n")
+	b.WriteString("# variable, e.g. SEEDR_DOWNLOAD_DIR for download.dir.\n")
+	b.WriteString("#\n")
+	b.WriteString("# Flag defaults of any command go in a section named after the command:\n")
+	b.WriteString("#   [add]\n#   jobs = 8\n#   [wishlist.auto]\n#   interval = \"10m\"\n\n")
+	section := ""
+	for _, opt := range ConfigOptions {
+		s, name := "", opt.Key
+		if i := strings.LastIndex(opt.Key, "."); i >= 0 {
+			s, name = opt.Key[:i], opt.Key[i+1:]
+		}
+		if s != section {
+			fmt.Fprintf(&b, "\n[%s]\n", s)
+			section = s
+		}
+		fmt.Fprintf(&b, "# %s\n# %s = %s\n", opt.Help, name, formatConfigValue(opt.Default))
+	}
+	return b.String()
+}
+
+// parseConfig reads the subset of TOML used by the config file: [section] headers, key = value
+// pairs with string, integer, boolean or single-line string array values, and # comments.
+// Keys are returned with their section as a dotted prefix; dotted keys are joined the same way.
+func parseConfig(data []byte) (map[string][]string, error) {
+	values := make(map[string][]string)
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			header := strings.TrimSpace(stripConfigComment(line))
+			if !strings.HasSuffix(header, "]") || strings.HasPrefix(header, "[[") {
+				return nil, fmt.Errorf("line %d: invalid section header", n)
+			}
+			var err error
+			if section, err = parseConfigKey(header[1 : len(header)-1]); err != nil {
+				return nil, fmt.Errorf("line %d: invalid section header: %w", n, err)
+			}
+			continue
+		}
+
+		k, raw, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", n)
+		}
+		key, err := parseConfigKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		if section != "" {
+			key = section + "." + key
+		}
+		v, err := parseConfigValue(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		values[key] = v
+	}
+	return values, scanner.Err()
+}
+
+// parseConfigKey parses a bare, quoted or dotted key such as `auto.interval` or `"my player"`.
+// The parts of a dotted key are joined with dots.
+func parseConfigKey(s string) (string, error) {
+	var parts []string
+	rest := strings.TrimSpace(s)
+	for {
+		var part string
+		if strings.HasPrefix(rest, `"`) || strings.HasPrefix(rest, "'") {
+			var err error
+			if part, rest, err = parseConfigScalar(rest); err != nil {
+				return "", err
+			}
+		} else {
+			end := strings.IndexFunc(rest, func(r rune) bool { return !isBareKeyChar(r) })
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return "", fmt.Errorf("missing key")
+			}
+			part, rest = rest[:end], rest[end:]
+		}
+		parts = append(parts, part)
+
+		rest = strings.TrimSpace(rest)
+		if rest == "" {
+			return strings.Join(parts, "."), nil
+		}
+		if !strings.HasPrefix(rest, ".") {
+			return "", fmt.Errorf("invalid key '%s'; quote keys with spaces or symbols", strings.TrimSpace(s))
+		}
+		rest = strings.TrimSpace(rest[1:])
+	}
+}
+
+func isBareKeyChar(r rune) bool {
+	return r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-'
+}
+
+// formatConfigKey writes a single key, quoting it unless it is a bare key.
+func formatConfigKey(name string) string {
+	if name != "" && strings.IndexFunc(name, func(r rune) bool { return !isBareKeyChar(r) }) < 0 {
+		return name
+	}
+	return quoteConfigString(name)
+}
+
+// formatConfigSection writes a section name such as "wishlist.auto" for a [section] header.
+func formatConfigSection(section string) string {
+	parts := strings.Split(section, ".")
+	for i, part := range parts {
+		parts[i] = formatConfigKey(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// parseConfigValue parses the value of a key = value line.
+func parseConfigValue(raw string) ([]string, error) {
+	if strings.HasPrefix(raw, "[") {
+		raw = strings.TrimSpace(stripConfigComment(raw))
+		if !strings.HasSuffix(raw, "]") {
+			return nil, fmt.Errorf("invalid array; arrays must be on one line")
+		}
+		var items []string
+		rest := strings.TrimSpace(raw[1 : len(raw)-1])
+		for rest != "" {
+			item, remainder, err := parseConfigScalar(rest)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+			rest = strings.TrimSpace(remainder)
+			if rest == "" {
+				break
+			}
+			if !strings.HasPrefix(rest, ",") {
+				return nil, fmt.Errorf("invalid array; expected ','")
+			}
+			rest = strings.TrimSpace(rest[1:])
+		}
+		return items, nil
+	}
+
+	v, rest, err := parseConfigScalar(raw)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(stripConfigComment(rest)) != "" {
+		return nil, fmt.Errorf("unexpected text after value: %s", strings.TrimSpace(rest))
+	}
+	return []string{v}, nil
+}
+
+// parseConfigScalar parses a string, number or boolean at the start of s and returns the rest.
+func parseConfigScalar(s string) (string, string, error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		for i := 1; i < len(s); i++ {
+			if s[i] == '\\' {
+				i++
+				continue
+			}
+			if s[i] == '"' {
+				v, err := strconv.Unquote(s[:i+1])
+				if err != nil {
+					return "", "", fmt.Errorf("invalid string %s", s[:i+1])
+				}
+				return v, s[i+1:], nil
+			}
+		}
+		return "", "", fmt.Errorf("unterminated string")
+	case strings.HasPrefix(s, "'"):
+		end := strings.Index(s[1:], "'")
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated string")
+		}
+		return s[1 : end+1], s[end+2:], nil
+	default:
+		end := strings.IndexAny(s, ",]#")
+		if end < 0 {
+			end = len(s)
+		}
+		v := strings.TrimSpace(s[:end])
+		if v == "true" || v == "false" {
+			return v, s[end:], nil
+		}
+		if _, err := strconv.ParseFloat(strings.ReplaceAll(v, "_", ""), 64); err == nil {
+			return strings.ReplaceAll(v, "_", ""), s[end:], nil
+		}
+		return "", "", fmt.Errorf("invalid value '%s'; quote strings", v)
+	}
+}
+
+// formatConfigValue writes a value the way parseConfig reads it back.
+func formatConfigValue(value string) string {
+	if value == "true" || value == "false" {
+		return value
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil && strconv.FormatInt(n, 10) == value {
+		return value // Not "08" or "+8", which TOML reads differently or not at all
+	}
+	return quoteConfigString(value)
+}
+
+// quoteConfigString writes s as a TOML basic string.
+func quoteConfigString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// stripConfigComment removes a trailing # comment from text outside of strings.
+func stripConfigComment(s string) string {
+	inString := byte(0)
+	for i := 0; i < len(s); i++ {
+		switch {
+		case inString == 0 && (s[i] == '"' || s[i] == '\''):
+			inString = s[i]
+		case inString == '"' && s[i] == '\\':
+			i++
+		case inString != 0 && s[i] == inString:
+			inString = 0
+		case inString == 0 && s[i] == '#':
+			return s[:i]
+		}
+	}
+	return s
+}
+
+func parseConfigDuration(value string, d *time.Duration) error {
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	if parsed <= 0 {
+		return fmt.Errorf("must be positive")
+	}
+	*d = parsed
+	return nil
+}
+
+// expandHome replaces a leading ~ with the user's home directory.
+func expandHome(p string) string {
+	if p == "~" || strings.HasPrefix(p, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, p[1:])
+		}
+	}
+	return p
+}
@@ -0,0 +1,291 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name, data string
+		want       map[string][]string
+	}{
+		{"empty", "", map[string][]string{}},
+		{"comments", "# top\n\n  # indented\n", map[string][]string{}},
+		{
+			"scalars",
+			"output = \"json\"\njobs = 8\nbig = 1_000\nratio = 0.5\nquiet = true\nlit = 'C:\\dir'\n",
+			map[string][]string{"output": {"json"}, "jobs": {"8"}, "big": {"1000"}, "ratio": {"0.5"}, "quiet": {"true"}, "lit": {`C:\dir`}},
+		},
+		{
+			"escapes",
+			`a = "say \"hi\"\tnow\\"` + "\n" + `b = "caf\u00e9 \U0001F600"` + "\n" + `c = "line\nbreak"`,
+			map[string][]string{"a": {"say \"hi\"\tnow\\"}, "b": {"café 😀"}, "c": {"line\nbreak"}},
+		},
+		{
+			"comments after values",
+			"a = \"x # not a comment\" # comment\nb = 'y # z' #\nc = 3 # three\nd = \"q\\\"#\" # c\n",
+			map[string][]string{"a": {"x # not a comment"}, "b": {"y # z"}, "c": {"3"}, "d": {`q"#`}},
+		},
+		{
+			"arrays",
+			"a = [\"x\", 'y', 3, true]\nb = []\nc = [ \"a,b\" , \"c]\" , ]\nd = [\"e\"] # see [docs]\n",
+			map[string][]string{"a": {"x", "y", "3", "true"}, "b": nil, "c": {"a,b", "c]"}, "d": {"e"}},
+		},
+		{
+			"tables",
+			"top = 1\n[add]\njobs = 4\n[ wishlist.auto ] # comment\ninterval = \"10m\"\n[handlers]\n\"my player\" = \"vlc {url}\"\n",
+			map[string][]string{"top": {"1"}, "add.jobs": {"4"}, "wishlist.auto.interval": {"10m"}, "handlers.my player": {"vlc {url}"}},
+		},
+		{
+			"dotted keys",
+			"download.dir = \"/tmp\"\n[wishlist]\nauto . interval = \"5m\"\n'quoted'.\"key\" = 1\n",
+			map[string][]string{"download.dir": {"/tmp"}, "wishlist.auto.interval": {"5m"}, "wishlist.quoted.key": {"1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseConfig([]byte(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseConfig = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	for _, data := range []string{
+		"key",
+		"= 1",
+		"key = ",
+		"key = bare",
+		"key = \"unterminated",
+		"key = 'unterminated",
+		"key = \"bad \\q escape\"",
+		"key = \"x\" trailing",
+		"key = [\"a\"",
+		"key = [\"a\" \"b\"]",
+		"key = [[\"nested\"]]",
+		"my key = 1",
+		"a..b = 1",
+		"[section",
+		"[]",
+		"[[array]]",
+		"[a b]",
+		"[ok]\nbroken",
+	} {
+		if got, err := parseConfig([]byte(data)); err == nil {
+			t.Errorf("parseConfig(%q) = %q, want an error", data, got)
+		}
+	}
+}
+
+func TestConfigValueRoundTrip(t *testing.T) {
+	for _, value := range []string{
+		"", "plain", "8", "-3", "08", "+8", "1_000", "0.5", "true", "false", "True",
+		`with "quotes"`, `back\slash`, "a # hash", "'single'", "tab\there", "new\nline", "cr\rlf",
+		"bell\a", "del\x7f", "café", "[not, an, array]", "{url}",
+	} {
+		formatted := formatConfigValue(value)
+		got, err := parseConfig([]byte("key = " + formatted))
+		if err != nil {
+			t.Errorf("formatConfigValue(%q) = %s, which does not parse: %v", value, formatted, err)
+			continue
+		}
+		if v := got["key"]; len(v) != 1 || v[0] != value {
+			t.Errorf("formatConfigValue(%q) = %s, which parses as %q", value, formatted, v)
+		}
+	}
+
+	for _, name := range []string{"jobs", "my-player", "my player", "a.b", `q"uote`, "", "ü"} {
+		got, err := parseConfig([]byte("[handlers]\n" + formatConfigKey(name) + " = 1"))
+		want := "handlers." + name
+		if _, ok := got[want]; err != nil || !ok {
+			t.Errorf("formatConfigKey(%q) = %s, which parses as %q, %v", name, formatConfigKey(name), got, err)
+		}
+	}
+}
+
+func TestSetConfigValue(t *testing.T) {
+	const original = `# seedr configuration
+output = "text" # default format
+
+[add]
+# number of parallel uploads
+jobs = 4
+paused = false
+
+[wishlist]
+auto.interval = "5m"
+
+[handlers]
+vlc = "vlc {url}"
+`
+	tests := []struct {
+		key, value string
+		want       string
+	}{
+		{"output", "json", `# seedr configuration
+output = "json" # default format
+
+[add]
+# number of parallel uploads
+jobs = 4
+paused = false
+
+[wishlist]
+auto.interval = "5m"
+
+[handlers]
+vlc = "vlc {url}"
+`},
+		{"add.jobs", "8", `# seedr configuration
+output = "text" # default format
+
+[add]
+# number of parallel uploads
+jobs = 8
+paused = false
+
+[wishlist]
+auto.interval = "5m"
+
+[handlers]
+vlc = "vlc {url}"
+`},
+		{"player.command", "mpv --fs", `# seedr configuration
+output = "text" # default format
+
+[add]
+# number of parallel uploads
+jobs = 4
+paused = false
+
+[wishlist]
+auto.interval = "5m"
+
+[handlers]
+vlc = "vlc {url}"
+
+[player]
+command = "mpv --fs"
+`},
+		{"add.target", `Movies "HD"`, `# seedr configuration
+output = "text" # default format
+
+[add]
+# number of parallel uploads
+jobs = 4
+paused = false
+target = "Movies \"HD\""
+
+[wishlist]
+auto.interval = "5m"
+
+[handlers]
+vlc = "vlc {url}"
+`},
+		{"wishlist.auto.interval", "10m", `# seedr configuration
+output = "text" # default format
+
+[add]
+# number of parallel uploads
+jobs = 4
+paused = false
+
+[wishlist]
+auto.interval = "10m"
+
+[handlers]
+vlc = "vlc {url}"
+`},
+		{"clipboard", "xclip", `# seedr configuration
+output = "text" # default format
+clipboard = "xclip"
+
+[add]
+# number of parallel uploads
+jobs = 4
+paused = false
+
+[wishlist]
+auto.interval = "5m"
+
+[handlers]
+vlc = "vlc {url}"
+`},
+		{"handlers.my player", "mpv #1", `# seedr configuration
+output = "text" # default format
+
+[add]
+# number of parallel uploads
+jobs = 4
+paused = false
+
+[wishlist]
+auto.interval = "5m"
+
+[handlers]
+vlc = "vlc {url}"
+"my player" = "mpv #1"
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.toml")
+			if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := SetConfigValue(path, tt.key, tt.value); err != nil {
+				t.Fatal(err)
+			}
+			data, _ := os.ReadFile(path)
+			if string(data) != tt.want {
+				t.Errorf("config after setting %s:\n%s\nwant:\n%s", tt.key, data, tt.want)
+			}
+
+			values, err := parseConfig(data)
+			if err != nil {
+				t.Fatalf("written config does not parse: %v", err)
+			}
+			if v := values[tt.key]; len(v) != 1 || v[0] != tt.value {
+				t.Errorf("%s reads back as %q, want %q", tt.key, v, tt.value)
+			}
+		})
+	}
+}
+
+func TestSetConfigValueNewFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles", "work", "config.toml")
+	for _, kv := range [][2]string{{"add.jobs", "2"}, {"output", "json"}, {"download.dir", "~/Videos"}, {"add.paused", "true"}} {
+		if err := SetConfigValue(path, kv[0], kv[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "output = \"json\"\n[add]\njobs = 2\npaused = true\n\n[download]\ndir = \"~/Videos\"\n"
+	if string(data) != want {
+		t.Errorf("new config:\n%s\nwant:\n%s", data, want)
+	}
+}
+
+func TestSetConfigValueInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	broken := "[add\njobs = 4\n"
+	os.WriteFile(path, []byte(broken), 0644)
+	if err := SetConfigValue(path, "add.jobs", "8"); err == nil || !strings.Contains(err.Error(), "config edit") {
+		t.Errorf("SetConfigValue on a broken file: error = %v, want a hint to fix it", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != broken {
+		t.Errorf("broken file was rewritten to %q", data)
+	}
+}
@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"fmt"
+	"os/exec"
+	"path"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Handler is a named command template that opens a file, such as "vlc {url}". Placeholders are
+// {url} (the download URL), {name} (the file name), {path} (the path in the account), {id} and
+// {start} (the saved playback position in seconds). The template is split into arguments on spaces
+// before the placeholders are filled in, so values containing spaces stay a single argument and no
+// shell is involved. Arguments with {start} are left out when there is no saved position.
+type Handler struct {
+	Name     string
+	Template string
+}
+
+// HandlerVars are the values substituted into a handler's template.
+type HandlerVars struct {
+	URL  string
+	Name string
+	Path string
+	ID   string
+	// Start is the position to resume playback from, 0 to start at the beginning
+	Start time.Duration
+}
+
+// PlayerHandler is the built-in handler running player.command.
+const PlayerHandler = "player"
+
+// builtinHandlers returns the handlers available without configuration.
+func builtinHandlers() map[string]string {
+	opener := "xdg-open {url}"
+	if runtime.GOOS == "darwin" {
+		opener = "open {url}"
+	}
+	player := Conf.Player
+	if IsMPV(player) && !strings.Contains(player, "{start}") {
+		player += " --start={start}"
+	}
+	if !strings.Contains(player, "{url}") {
+		player += " {url}"
+	}
+	return map[string]string{
+		PlayerHandler: player,
+		"mpv":         "mpv --title={name} --start={start} {url}",
+		"vlc":         "vlc --meta-title={name} --start-time={start} {url}",
+		"browser":     opener,
+	}
+}
+
+// builtinFileTypes maps file types to the handlers used for them without configuration.
+var builtinFileTypes = map[string]string{
+	"video":   PlayerHandler,
+	"audio":   PlayerHandler,
+	"default": "browser",
+}
+
+// fileTypeExtensions groups the extensions that file type names in [filetypes] stand for.
+var fileTypeExtensions = map[string][]string{
+	"video":    {"mkv", "mp4", "m4v", "avi", "mov", "webm", "wmv", "flv", "ts", "m2ts", "mpg", "mpeg", "ogv"},
+	"audio":    {"mp3", "flac", "m4a", "aac", "ogg", "oga", "opus", "wav", "wma"},
+	"image":    {"jpg", "jpeg", "png", "gif", "webp", "bmp"},
+	"document": {"pdf", "epub", "txt", "nfo"},
+}
+
+// FileType returns the file type of a file name ("video", "audio", "image", "document"), or "" if
+// its extension is not known.
+func FileType(name string) string {
+	ext := strings.ToLower(strings.TrimPrefix(path.Ext(name), "."))
+	for fileType, exts := range fileTypeExtensions {
+		for _, e := range exts {
+			if e == ext {
+				return fileType
+			}
+		}
+	}
+	return ""
+}
+
+// Handlers returns the built-in handlers merged with those configured in [handlers], sorted by name.
+func Handlers() []Handler {
+	templates := builtinHandlers()
+	for name, template := range Conf.Table("handlers") {
+		templates[name] = template
+	}
+	handlers := make([]Handler, 0, len(templates))
+	for name, template := range templates {
+		handlers = append(handlers, Handler{Name: name, Template: template})
+	}
+	sort.Slice(handlers, func(i, j int) bool { return handlers[i].Name < handlers[j].Name })
+	return handlers
+}
+
+// FindHandler returns the handler with the given name. Anything containing a placeholder or a space
+// is taken as an ad-hoc template, e.g. "mpv --fs {url}".
+func FindHandler(nameOrTemplate string) (Handler, error) {
+	for _, h := range Handlers() {
+		if h.Name == nameOrTemplate {
+			return h, nil
+		}
+	}
+	if strings.ContainsAny(nameOrTemplate, "{ ") {
+		return Handler{Name: strings.Fields(nameOrTemplate)[0], Template: nameOrTemplate}, nil
+	}
+	return Handler{}, fmt.Errorf("no handler named '%s'; add one under [handlers] in the config file", nameOrTemplate)
+}
+
+// FileTypeHandlers returns the built-in [filetypes] entries merged with the configured ones:
+// extensions, file types and "default", mapped to handler names.
+func FileTypeHandlers() map[string]string {
+	fileTypes := make(map[string]string, len(builtinFileTypes))
+	for k, v := range builtinFileTypes {
+		fileTypes[k] = v
+	}
+	for k, v := range Conf.Table("filetypes") {
+		fileTypes[strings.ToLower(strings.TrimPrefix(k, "."))] = v
+	}
+	return fileTypes
+}
+
+// HandlerFor returns the handler configured for a file, looking up its extension, then its file
+// type, then "default" in [filetypes].
+func HandlerFor(fileName string) (Handler, error) {
+	fileTypes := FileTypeHandlers()
+	ext := strings.ToLower(strings.TrimPrefix(path.Ext(fileName), "."))
+	for _, key := range []string{ext, FileType(fileName), "default"} {
+		if name, ok := fileTypes[key]; ok && key != "" {
+			return FindHandler(name)
+		}
+	}
+	return FindHandler("browser")
+}
+
+// Command builds the command for a file from the handler's template.
+func (h Handler) Command(vars HandlerVars) (*exec.Cmd, error) {
+	fields := strings.Fields(h.Template)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("handler '%s' has an empty command", h.Name)
+	}
+	start := strconv.Itoa(int(vars.Start.Seconds()))
+	replacer := strings.NewReplacer("{url}", vars.URL, "{name}", vars.Name, "{path}", vars.Path, "{id}", vars.ID, "{start}", start)
+	args := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if vars.Start <= 0 && strings.Contains(f, "{start}") {
+			continue
+		}
+		args = append(args, replacer.Replace(f))
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("handler '%s' has an empty command", h.Name)
+	}
+	return exec.Command(args[0], args[1:]...), nil
+}
+
+// Start runs the handler for a file in the background without waiting for it to exit.
+func (h Handler) Start(vars HandlerVars) error {
+	cmd, err := h.Command(vars)
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", cmd.Args[0], err)
+	}
+	go cmd.Wait() // Reap the process once it exits
+	return nil
+}
@@ -0,0 +1,415 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// mpvScheme prefixes the placeholder URLs queued in mpv. They are replaced by a fresh download URL
+// in mpv's on_load hook when the entry starts, since download URLs expire.
+const mpvScheme = "seedr://file/"
+
+// Property observer IDs and hook IDs sent to mpv.
+const (
+	mpvObservePause = iota + 1
+	mpvObserveTitle
+	mpvObservePos
+	mpvObserveCount
+	mpvObserveIdle
+
+	mpvHookLoad   = 1
+	mpvHookUnload = 2
+)
+
+// PlaylistEntry is a file to queue in mpv.
+type PlaylistEntry struct {
+	ID    string
+	Name  string
+	Start time.Duration // Position to resume from
+}
+
+// PlayerState is what mpv is playing.
+type PlayerState struct {
+	Title  string
+	Paused bool
+	Idle   bool // Nothing is loaded
+	Pos    int  // Index of the current entry in the playlist, -1 if none
+	Count  int  // Number of entries in the playlist
+}
+
+// PlayerSource provides the files queued in mpv.
+type PlayerSource interface {
+	// FileURL returns the download URL of a file.
+	FileURL(id string) (string, error)
+}
+
+// MPV controls an mpv instance through its JSON IPC socket. Files are queued as placeholders and
+// resolved to download URLs only when mpv starts playing them.
+type MPV struct {
+	conn net.Conn
+
+	mu        sync.Mutex // Guards the fields below and writes to conn
+	source    PlayerSource
+	nextID    int
+	pending   map[int]chan mpvMessage
+	state     PlayerState
+	updates   chan PlayerState
+	closed    bool
+	closeErr  error
+	starts    map[string]time.Duration // Position to resume each queued file from, by ID
+	currentID string                   // The file playing, if it was queued by us
+}
+
+// mpvMessage is a reply or an event read from the socket.
+type mpvMessage struct {
+	Event     string          `json:"event"`
+	ID        int             `json:"id"`
+	Name      string          `json:"name"`
+	Data      json.RawMessage `json:"data"`
+	HookID    int64           `json:"hook_id"`
+	RequestID int             `json:"request_id"`
+	Error     string          `json:"error"`
+}
+
+// IsMPV reports whether a player command runs mpv.
+func IsMPV(player string) bool {
+	fields := strings.Fields(player)
+	if len(fields) == 0 {
+		return false
+	}
+	return strings.TrimSuffix(filepath.Base(fields[0]), ".exe") == "mpv"
+}
+
+// MPVSocketPath returns the path of the IPC socket of the mpv instance started by seedr.
+func MPVSocketPath() (string, error) {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "seedr-mpv.sock"), nil
+	}
+	dir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mpv.sock"), nil
+}
+
+// StartMPV connects to the mpv instance started by an earlier call, possibly from another seedr
+// process, or starts a new one in idle mode with the configured player command.
+func StartMPV(source PlayerSource) (*MPV, error) {
+	if !IsMPV(Conf.Player) {
+		return nil, fmt.Errorf("queueing files needs mpv as player.command, not '%s'", Conf.Player)
+	}
+	socket, err := MPVSocketPath()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		os.Remove(socket) // Left behind by an mpv that has exited
+		var args []string
+		for _, f := range strings.Fields(Conf.Player) {
+			if !strings.Contains(f, "{") {
+				args = append(args, f)
+			}
+		}
+		args = append(args, "--idle=yes", "--force-window=yes", "--input-ipc-server="+socket)
+		cmd := exec.Command(args[0], args[1:]...)
+		if err := cmd.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start %s: %w", args[0], err)
+		}
+		go cmd.Wait() // Reap the process once it exits
+
+		for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(100 * time.Millisecond) {
+			if conn, err = net.Dial("unix", socket); err == nil {
+				break
+			}
+			if time.Now().After(deadline) {
+				return nil, fmt.Errorf("mpv did not open its IPC socket %s: %w", socket, err)
+			}
+		}
+	}
+
+	p := newMPV(conn, source)
+	setup := [][]any{
+		{"hook-add", "on_load", mpvHookLoad, 0},
+		{"hook-add", "on_unload", mpvHookUnload, 0},
+		{"observe_property", mpvObservePause, "pause"},
+		{"observe_property", mpvObserveTitle, "media-title"},
+		{"observe_property", mpvObservePos, "playlist-pos"},
+		{"observe_property", mpvObserveCount, "playlist-count"},
+		{"observe_property", mpvObserveIdle, "idle-active"},
+	}
+	for _, args := range setup {
+		if _, err := p.Command(args...); err != nil {
+			p.Close()
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// newMPV returns an MPV talking to mpv over conn, reading its replies and events in the background.
+func newMPV(conn net.Conn, source PlayerSource) *MPV {
+	p := &MPV{
+		conn:    conn,
+		source:  source,
+		pending: make(map[int]chan mpvMessage),
+		state:   PlayerState{Idle: true, Pos: -1},
+		updates: make(chan PlayerState, 1),
+		starts:  make(map[string]time.Duration),
+	}
+	go p.readLoop()
+	return p
+}
+
+// SetSource replaces the source of queued files, e.g. after switching accounts.
+func (p *MPV) SetSource(source PlayerSource) {
+	p.mu.Lock()
+	p.source = source
+	p.mu.Unlock()
+}
+
+// Updates returns a channel receiving the player state whenever it changes. Only the latest state
+// is kept for a slow reader. The channel is closed once mpv exits or the connection is closed.
+func (p *MPV) Updates() <-chan PlayerState {
+	return p.updates
+}
+
+// State returns the current player state.
+func (p *MPV) State() PlayerState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state
+}
+
+// Load queues files in mpv. With replace, the playlist is replaced and the first file starts
+// playing; otherwise the files are appended and playback starts if mpv is idle.
+func (p *MPV) Load(entries []PlaylistEntry, replace bool) error {
+	p.mu.Lock()
+	for _, e := range entries {
+		p.starts[e.ID] = e.Start
+	}
+	p.mu.Unlock()
+
+	for i, e := range entries {
+		mode := "append-play"
+		if replace {
+			mode = "append"
+			if i == 0 {
+				mode = "replace"
+			}
+		}
+		placeholder := mpvScheme + url.PathEscape(e.ID) + "/" + url.PathEscape(e.Name)
+		if _, err := p.Command("loadfile", placeholder, mode); err != nil {
+			return fmt.Errorf("failed to queue %s: %w", e.Name, err)
+		}
+	}
+	return nil
+}
+
+// TogglePause pauses or resumes playback.
+func (p *MPV) TogglePause() error {
+	_, err := p.Command("cycle", "pause")
+	return err
+}
+
+// Next skips to the next playlist entry.
+func (p *MPV) Next() error {
+	_, err := p.Command("playlist-next")
+	return err
+}
+
+// Command runs an mpv input command and returns its result.
+func (p *MPV) Command(args ...any) (json.RawMessage, error) {
+	reply := make(chan mpvMessage, 1)
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("mpv is not running: %w", p.closeErr)
+	}
+	p.nextID++
+	id := p.nextID
+	p.pending[id] = reply
+	line, err := json.Marshal(map[string]any{"command": args, "request_id": id})
+	if err == nil {
+		_, err = p.conn.Write(append(line, '\n'))
+	}
+	if err != nil {
+		delete(p.pending, id)
+		p.mu.Unlock()
+		return nil, fmt.Errorf("failed to send %v to mpv: %w", args[0], err)
+	}
+	p.mu.Unlock()
+
+	select {
+	case msg, ok := <-reply:
+		if !ok {
+			return nil, fmt.Errorf("mpv exited before answering %v", args[0])
+		}
+		if msg.Error != "success" {
+			return nil, fmt.Errorf("mpv: %v: %s", args[0], msg.Error)
+		}
+		return msg.Data, nil
+	case <-time.After(10 * time.Second):
+		p.mu.Lock()
+		delete(p.pending, id)
+		p.mu.Unlock()
+		return nil, fmt.Errorf("mpv did not answer %v", args[0])
+	}
+}
+
+// Close disconnects from mpv, which keeps playing. Queued files that have not started yet can no
+// longer be resolved.
+func (p *MPV) Close() error {
+	return p.conn.Close()
+}
+
+// readLoop dispatches replies and events until the connection is closed.
+func (p *MPV) readLoop() {
+	scanner := bufio.NewScanner(p.conn)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var msg mpvMessage
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			DebugLog("Ignoring invalid mpv message %q: %v", scanner.Text(), err)
+			continue
+		}
+		switch msg.Event {
+		case "":
+			p.mu.Lock()
+			if reply, ok := p.pending[msg.RequestID]; ok {
+				delete(p.pending, msg.RequestID)
+				reply <- msg
+			}
+			p.mu.Unlock()
+		case "hook":
+			// Commands can't be answered while this loop is busy, so handle hooks elsewhere
+			switch msg.ID {
+			case mpvHookLoad:
+				go p.onLoad(msg.HookID)
+			case mpvHookUnload:
+				go p.onUnload(msg.HookID)
+			}
+		case "property-change":
+			p.propertyChanged(msg)
+		}
+	}
+
+	err := scanner.Err()
+	if err == nil {
+		err = errors.New("connection closed")
+	}
+	p.mu.Lock()
+	p.closed, p.closeErr = true, err
+	for id, reply := range p.pending {
+		close(reply)
+		delete(p.pending, id)
+	}
+	p.mu.Unlock()
+	close(p.updates)
+}
+
+// propertyChanged records an observed property and publishes the new state.
+func (p *MPV) propertyChanged(msg mpvMessage) {
+	p.mu.Lock()
+	switch msg.ID {
+	case mpvObservePause:
+		json.Unmarshal(msg.Data, &p.state.Paused)
+	case mpvObserveTitle:
+		p.state.Title = ""
+		json.Unmarshal(msg.Data, &p.state.Title)
+	case mpvObservePos:
+		p.state.Pos = -1
+		json.Unmarshal(msg.Data, &p.state.Pos)
+	case mpvObserveCount:
+		json.Unmarshal(msg.Data, &p.state.Count)
+	case mpvObserveIdle:
+		json.Unmarshal(msg.Data, &p.state.Idle)
+	}
+	state := p.state
+	p.mu.Unlock()
+
+	// Replace an update the reader has not picked up yet
+	select {
+	case <-p.updates:
+	default:
+	}
+	p.updates <- state
+}
+
+// onLoad runs for every file mpv is about to open. Placeholders are replaced by a fresh download
+// URL and the file name is shown as the title; anything else is left to mpv.
+func (p *MPV) onLoad(hookID int64) {
+	defer p.Command("hook-ack", hookID)
+
+	data, err := p.Command("get_property", "stream-open-filename")
+	if err != nil {
+		return
+	}
+	var filename string
+	if err := json.Unmarshal(data, &filename); err != nil || !strings.HasPrefix(filename, mpvScheme) {
+		return
+	}
+	id, name, _ := strings.Cut(strings.TrimPrefix(filename, mpvScheme), "/")
+	id, _ = url.PathUnescape(id)
+	name, _ = url.PathUnescape(name)
+
+	p.mu.Lock()
+	source, start := p.source, p.starts[id]
+	p.mu.Unlock()
+	fileURL, err := source.FileURL(id)
+	if err != nil {
+		DebugLog("Resolving %s for mpv failed: %v", name, err)
+		return // mpv fails to open the placeholder and moves on to the next entry
+	}
+	p.Command("set_property", "stream-open-filename", fileURL)
+	p.Command("set_property", "file-local-options/force-media-title", name)
+	if start > 0 {
+		p.Command("set_property", "file-local-options/start", fmt.Sprintf("+%d", int(start.Seconds())))
+	}
+	p.mu.Lock()
+	p.currentID = id
+	p.mu.Unlock()
+}
+
+// onUnload runs when mpv stops playing a file, while its position can still be read.
+func (p *MPV) onUnload(hookID int64) {
+	defer p.Command("hook-ack", hookID)
+	p.rememberPosition()
+}
+
+// rememberPosition records the position of the file playing, to resume from when the file is
+// queued again in this session. Seedr's API offers no way to save it with the account.
+func (p *MPV) rememberPosition() {
+	p.mu.Lock()
+	id := p.currentID
+	p.currentID = ""
+	p.mu.Unlock()
+	if id == "" {
+		return
+	}
+
+	var position, duration float64
+	data, err := p.Command("get_property", "time-pos")
+	if err != nil || json.Unmarshal(data, &position) != nil {
+		return
+	}
+	if data, err := p.Command("get_property", "duration"); err == nil {
+		json.Unmarshal(data, &duration)
+	}
+	pos := WatchedPosition(time.Duration(position*float64(time.Second)), time.Duration(duration*float64(time.Second)))
+
+	p.mu.Lock()
+	p.starts[id] = pos
+	p.mu.Unlock()
+}
@@ -0,0 +1,252 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeMPV is the mpv end of an IPC connection. Tests read the commands it receives and answer them.
+type fakeMPV struct {
+	t        *testing.T
+	conn     net.Conn
+	requests chan fakeMPVRequest
+}
+
+type fakeMPVRequest struct {
+	Command   []any `json:"command"`
+	RequestID int   `json:"request_id"`
+}
+
+// name returns the command and its arguments as a single string, e.g. "get_property time-pos".
+func (r fakeMPVRequest) name() string {
+	parts := make([]string, len(r.Command))
+	for i, arg := range r.Command {
+		parts[i] = fmt.Sprint(arg)
+	}
+	return strings.Join(parts, " ")
+}
+
+// testSource is a PlayerSource resolving every ID but "missing" to a URL on dl.example.
+type testSource struct{}
+
+func (testSource) FileURL(id string) (string, error) {
+	if id == "missing" {
+		return "", fmt.Errorf("no such file")
+	}
+	return "https://dl.example/" + id, nil
+}
+
+// newTestMPV returns an MPV connected to a fake mpv.
+func newTestMPV(t *testing.T) (*MPV, *fakeMPV) {
+	t.Helper()
+	client, server := net.Pipe()
+	fake := &fakeMPV{t: t, conn: server, requests: make(chan fakeMPVRequest, 100)}
+	go func() {
+		scanner := bufio.NewScanner(server)
+		for scanner.Scan() {
+			var req fakeMPVRequest
+			if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+				t.Errorf("invalid command %q: %v", scanner.Text(), err)
+				continue
+			}
+			fake.requests <- req
+		}
+		close(fake.requests)
+	}()
+	p := newMPV(client, testSource{})
+	t.Cleanup(func() { server.Close() })
+	return p, fake
+}
+
+// next returns the next command sent to mpv.
+func (f *fakeMPV) next() fakeMPVRequest {
+	f.t.Helper()
+	select {
+	case req, ok := <-f.requests:
+		if !ok {
+			f.t.Fatal("connection closed while waiting for a command")
+		}
+		return req
+	case <-time.After(5 * time.Second):
+		f.t.Fatal("timed out waiting for a command")
+	}
+	return fakeMPVRequest{}
+}
+
+// expect reads the next command, fails the test unless it is want, and answers it with data.
+func (f *fakeMPV) expect(want string, data any) {
+	f.t.Helper()
+	req := f.next()
+	if req.name() != want {
+		f.t.Fatalf("mpv received %q, want %q", req.name(), want)
+	}
+	f.reply(req.RequestID, data, "success")
+}
+
+func (f *fakeMPV) reply(requestID int, data any, status string) {
+	f.t.Helper()
+	f.send(map[string]any{"request_id": requestID, "data": data, "error": status})
+}
+
+func (f *fakeMPV) send(msg any) {
+	f.t.Helper()
+	line, _ := json.Marshal(msg)
+	if _, err := f.conn.Write(append(line, '\n')); err != nil {
+		f.t.Fatalf("writing to the client: %v", err)
+	}
+}
+
+func TestMPVCommandReplies(t *testing.T) {
+	p, fake := newTestMPV(t)
+
+	type result struct {
+		data string
+		err  error
+	}
+	results := make(map[string]chan result)
+	for _, prop := range []string{"path", "volume"} {
+		done := make(chan result, 1)
+		results[prop] = done
+		go func() {
+			data, err := p.Command("get_property", prop)
+			done <- result{string(data), err}
+		}()
+	}
+
+	// Answer in the opposite order of the requests
+	first, second := fake.next(), fake.next()
+	fake.reply(second.RequestID, second.Command[1], "success")
+	fake.reply(first.RequestID, first.Command[1], "success")
+	for prop, done := range results {
+		if r := <-done; r.err != nil || r.data != fmt.Sprintf("%q", prop) {
+			t.Errorf("get_property %s = %s, %v; want its own reply", prop, r.data, r.err)
+		}
+	}
+
+	// A reply nobody waits for is ignored
+	fake.reply(999, nil, "success")
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := p.Command("get_property", "nothing")
+		done <- err
+	}()
+	req := fake.next()
+	fake.reply(req.RequestID, nil, "property unavailable")
+	if err := <-done; err == nil || !strings.Contains(err.Error(), "property unavailable") {
+		t.Errorf("failed command: error = %v, want mpv's error", err)
+	}
+}
+
+func TestMPVLoadHook(t *testing.T) {
+	p, fake := newTestMPV(t)
+
+	loaded := make(chan error, 1)
+	go func() {
+		loaded <- p.Load([]PlaylistEntry{{ID: "11", Name: "The Show S01E02.mkv", Start: 90 * time.Second}}, true)
+	}()
+	req := fake.next()
+	placeholder := "seedr://file/11/The%20Show%20S01E02.mkv"
+	if req.name() != "loadfile "+placeholder+" replace" {
+		t.Fatalf("Load sent %q, want the placeholder replacing the playlist", req.name())
+	}
+	fake.reply(req.RequestID, nil, "success")
+	if err := <-loaded; err != nil {
+		t.Fatal(err)
+	}
+
+	fake.send(map[string]any{"event": "hook", "id": mpvHookLoad, "hook_id": 7})
+	fake.expect("get_property stream-open-filename", placeholder)
+	fake.expect("set_property stream-open-filename https://dl.example/11", nil)
+	fake.expect("set_property file-local-options/force-media-title The Show S01E02.mkv", nil)
+	fake.expect("set_property file-local-options/start +90", nil)
+	fake.expect("hook-ack 7", nil)
+
+	// Files not queued by us are left alone
+	fake.send(map[string]any{"event": "hook", "id": mpvHookLoad, "hook_id": 8})
+	fake.expect("get_property stream-open-filename", "https://other.example/video.mkv")
+	fake.expect("hook-ack 8", nil)
+
+	// A file that can't be resolved is left for mpv to fail on
+	fake.send(map[string]any{"event": "hook", "id": mpvHookLoad, "hook_id": 9})
+	fake.expect("get_property stream-open-filename", "seedr://file/missing/x.mkv")
+	fake.expect("hook-ack 9", nil)
+}
+
+func TestMPVUnloadHookRemembersPosition(t *testing.T) {
+	p, fake := newTestMPV(t)
+
+	fake.send(map[string]any{"event": "hook", "id": mpvHookLoad, "hook_id": 1})
+	fake.expect("get_property stream-open-filename", "seedr://file/11/x.mkv")
+	fake.expect("set_property stream-open-filename https://dl.example/11", nil)
+	fake.expect("set_property file-local-options/force-media-title x.mkv", nil)
+	fake.expect("hook-ack 1", nil)
+
+	fake.send(map[string]any{"event": "hook", "id": mpvHookUnload, "hook_id": 2})
+	fake.expect("get_property time-pos", 600.5)
+	fake.expect("get_property duration", 3600)
+	fake.expect("hook-ack 2", nil)
+
+	p.mu.Lock()
+	start := p.starts["11"]
+	p.mu.Unlock()
+	if want := WatchedPosition(600500*time.Millisecond, time.Hour); start != want {
+		t.Errorf("start for the next load = %v, want %v", start, want)
+	}
+}
+
+func TestMPVPropertyUpdates(t *testing.T) {
+	p, fake := newTestMPV(t)
+	fake.send(map[string]any{"event": "property-change", "id": mpvObserveTitle, "name": "media-title", "data": "x.mkv"})
+	fake.send(map[string]any{"event": "property-change", "id": mpvObserveIdle, "name": "idle-active", "data": false})
+
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case state := <-p.Updates():
+			if state.Title == "x.mkv" && !state.Idle {
+				return
+			}
+		case <-deadline:
+			t.Fatalf("state = %+v, want the title and idle-active from mpv", p.State())
+		}
+	}
+}
+
+func TestMPVClosedConnection(t *testing.T) {
+	p, fake := newTestMPV(t)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := p.Command("get_property", "time-pos")
+		done <- err
+	}()
+	fake.next()
+	fake.conn.Close() // mpv exits without answering
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Errorf("pending command succeeded after mpv exited")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("pending command still blocked after the connection closed")
+	}
+
+	deadline := time.After(5 * time.Second)
+	for open := true; open; {
+		select {
+		case _, open = <-p.Updates():
+		case <-deadline:
+			t.Fatal("updates channel not closed after the connection closed")
+		}
+	}
+	if _, err := p.Command("get_property", "pause"); err == nil || !strings.Contains(err.Error(), "not running") {
+		t.Errorf("command after mpv exited: error = %v, want \"not running\"", err)
+	}
+}
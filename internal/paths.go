@@ -0,0 +1,135 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const tokenFileName = "token.txt"
+
+// xdgDir returns $<envVar>/seedr, or ~/<fallback>/seedr when the variable is unset, as the XDG
+// base directory specification describes.
+func xdgDir(envVar, fallback string) (string, error) {
+	if dir := os.Getenv(envVar); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "seedr"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get user home directory: %w", err)
+	}
+	return filepath.Join(homeDir, fallback, "seedr"), nil
+}
+
+// ConfigDir returns the directory holding the config files ($XDG_CONFIG_HOME/seedr).
+func ConfigDir() (string, error) {
+	return xdgDir("XDG_CONFIG_HOME", ".config")
+}
+
+// CacheDir returns the directory holding logs and other disposable files ($XDG_CACHE_HOME/seedr),
+// creating it if needed.
+func CacheDir() (string, error) {
+	dir, err := xdgDir("XDG_CACHE_HOME", ".cache")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("could not create %s: %w", dir, err)
+	}
+	return dir, nil
+}
+
+var migrateOnce sync.Once
+
+// baseDir returns the directory holding the tokens and other state of all profiles
+// ($XDG_STATE_HOME/seedr), without creating it.
+func baseDir() (string, error) {
+	dir, err := xdgDir("XDG_STATE_HOME", filepath.Join(".local", "state"))
+	if err != nil {
+		return "", err
+	}
+	migrateOnce.Do(func() { migrateLegacyState(dir) })
+	return dir, nil
+}
+
+// migrateLegacyState moves the state kept in ~/.cache/seedr by earlier versions to dir, unless dir
+// already exists. Failures are only logged; the old files are then simply not found.
+func migrateLegacyState(dir string) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	legacy := filepath.Join(homeDir, ".cache", "seedr")
+	if legacy == dir {
+		return
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		return
+	}
+	for _, name := range []string{tokenFileName, "wishlist.log", "current-profile", "profiles"} {
+		from := filepath.Join(legacy, name)
+		if _, err := os.Stat(from); err != nil {
+			continue
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			DebugLog("Could not create %s: %v", dir, err)
+			return
+		}
+		if err := os.Rename(from, filepath.Join(dir, name)); err != nil {
+			DebugLog("Could not move %s to %s: %v", from, dir, err)
+		}
+	}
+}
+
+// SeedrDir returns the directory holding the active profile's token and other local state,
+// creating it if needed.
+func SeedrDir() (string, error) {
+	name := ActiveProfile()
+	exists, err := ProfileExists(name)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", fmt.Errorf("no profile named '%s'; add it with `seedr profile add %s`", name, name)
+	}
+	dir, err := ProfileDir(name)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("could not create %s: %w", dir, err)
+	}
+	return dir, nil
+}
+
+// TokenPath returns the location of the active profile's saved token.
+func TokenPath() (string, error) {
+	dir, err := SeedrDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, tokenFileName), nil
+}
+
+// WishlistLogPath returns the location of the log of automatically promoted wishlist items.
+func WishlistLogPath() (string, error) {
+	dir, err := SeedrDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "wishlist.log"), nil
+}
+
+// LogDir returns the directory debug logs are written to, creating it if needed.
+func LogDir() (string, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+	dir = filepath.Join(dir, "logs")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("could not create %s: %w", dir, err)
+	}
+	return dir, nil
+}
@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PlaylistFormats are the formats WritePlaylist supports.
+var PlaylistFormats = []string{"m3u", "xspf"}
+
+// PlaylistItem is an entry of a playlist file.
+type PlaylistItem struct {
+	Title string
+	URL   string
+}
+
+// WritePlaylist writes items as an M3U or XSPF playlist titled title.
+func WritePlaylist(w io.Writer, format, title string, items []PlaylistItem) error {
+	switch format {
+	case "m3u":
+		return writeM3U(w, title, items)
+	case "xspf":
+		return writeXSPF(w, title, items)
+	}
+	return fmt.Errorf("unknown playlist format '%s', expected %s", format, strings.Join(PlaylistFormats, " or "))
+}
+
+// writeM3U writes an extended M3U playlist.
+func writeM3U(w io.Writer, title string, items []PlaylistItem) error {
+	var b strings.Builder
+	b.WriteString("#EXTM3U\n")
+	// Line breaks in a title would end the directive early
+	oneLine := func(s string) string { return strings.Join(strings.Fields(s), " ") }
+	if title := oneLine(title); title != "" {
+		fmt.Fprintf(&b, "#PLAYLIST:%s\n", title)
+	}
+	for _, item := range items {
+		fmt.Fprintf(&b, "#EXTINF:-1,%s\n%s\n", oneLine(item.Title), item.URL)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type xspfPlaylist struct {
+	XMLName xml.Name    `xml:"playlist"`
+	Version string      `xml:"version,attr"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	Title   string      `xml:"title,omitempty"`
+	Tracks  []xspfTrack `xml:"trackList>track"`
+}
+
+type xspfTrack struct {
+	Location string `xml:"location"`
+	Title    string `xml:"title,omitempty"`
+}
+
+// writeXSPF writes an XSPF (XML Shareable Playlist Format) playlist.
+func writeXSPF(w io.Writer, title string, items []PlaylistItem) error {
+	playlist := xspfPlaylist{Version: "1", XMLNS: "http://xspf.org/ns/0/", Title: title}
+	for _, item := range items {
+		playlist.Tracks = append(playlist.Tracks, xspfTrack{Location: item.URL, Title: item.Title})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(playlist); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// NaturalLess reports whether a sorts before b, comparing runs of digits by their numeric value so
+// that "Episode 2" comes before "Episode 10". Letters are compared without regard to case.
+func NaturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			na, nb := digitPrefix(a), digitPrefix(b)
+			ta, tb := strings.TrimLeft(na, "0"), strings.TrimLeft(nb, "0")
+			if len(ta) != len(tb) {
+				return len(ta) < len(tb)
+			}
+			if ta != tb {
+				return ta < tb
+			}
+			if len(na) != len(nb) {
+				return len(na) < len(nb) // Fewer leading zeros first
+			}
+			a, b = a[len(na):], b[len(nb):]
+			continue
+		}
+		ca, cb := strings.ToLower(a[:1]), strings.ToLower(b[:1])
+		if ca != cb {
+			return ca < cb
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
+
+// digitPrefix returns the leading run of digits of s.
+func digitPrefix(s string) string {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return s[:i]
+}
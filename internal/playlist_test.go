@@ -0,0 +1,147 @@
+package internal
+
+import (
+	"encoding/xml"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestNaturalLess(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"Episode 2", "Episode 10", true},
+		{"Episode 10", "Episode 2", false},
+		{"S01E09", "S01E10", true},
+		{"S2E1", "S10E1", true},
+		{"a1b2", "a1b10", true},
+		{"file 007", "file 8", true},
+		{"file 08", "file 8", false},
+		{"file 8", "file 08", true}, // Equal numbers: fewer leading zeros first
+		{"file 0", "file 00", true},
+		{"99999999999999999999", "100000000000000000000", true}, // Longer than an int64
+		{"apple", "Banana", true},
+		{"Apple", "banana", true},
+		{"a", "A", false},
+		{"A", "a", false},
+		{"abc", "abcd", true},
+		{"abcd", "abc", false},
+		{"", "a", true},
+		{"a", "", false},
+		{"", "", false},
+		{"x 1", "x a", true}, // Digits sort before letters
+		{"x.2", "x 10", false},
+	}
+	for _, tt := range tests {
+		if got := NaturalLess(tt.a, tt.b); got != tt.want {
+			t.Errorf("NaturalLess(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	names := []string{"Ep 10.mkv", "ep 1.mkv", "Ep 2.mkv", "Ep 02.mkv", "Extras", "Ep 1b.mkv"}
+	sort.SliceStable(names, func(i, j int) bool { return NaturalLess(names[i], names[j]) })
+	want := []string{"ep 1.mkv", "Ep 1b.mkv", "Ep 2.mkv", "Ep 02.mkv", "Ep 10.mkv", "Extras"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("sorted = %q, want %q", names, want)
+	}
+}
+
+func TestWriteM3U(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		items []PlaylistItem
+		want  string
+	}{
+		{"empty", "", nil, "#EXTM3U\n"},
+		{
+			"items",
+			"Movies",
+			[]PlaylistItem{{"a.mkv", "https://dl.example/1"}, {"b, c.mkv", "https://dl.example/2?x=1&y=2"}},
+			"#EXTM3U\n#PLAYLIST:Movies\n#EXTINF:-1,a.mkv\nhttps://dl.example/1\n#EXTINF:-1,b, c.mkv\nhttps://dl.example/2?x=1&y=2\n",
+		},
+		{
+			"line breaks in titles",
+			"My\nList\r\n",
+			[]PlaylistItem{{"Line one\nhttps://evil.example\r\n#EXTINF:-1,x", "https://dl.example/1"}, {"\t", "https://dl.example/2"}},
+			"#EXTM3U\n#PLAYLIST:My List\n#EXTINF:-1,Line one https://evil.example #EXTINF:-1,x\nhttps://dl.example/1\n#EXTINF:-1,\nhttps://dl.example/2\n",
+		},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		if err := WritePlaylist(&b, "m3u", tt.title, tt.items); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("%s: playlist = %q, want %q", tt.name, b.String(), tt.want)
+		}
+	}
+}
+
+func TestWriteXSPF(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		items []PlaylistItem
+		want  []string // Must appear in the output
+	}{
+		{
+			"escaping",
+			"Tom & Jerry <3",
+			[]PlaylistItem{{"Fast & Furious <2>", "https://dl.example/1?a=1&b=<2>"}},
+			[]string{
+				"<title>Tom &amp; Jerry &lt;3</title>",
+				"<location>https://dl.example/1?a=1&amp;b=&lt;2&gt;</location>",
+				"<title>Fast &amp; Furious &lt;2&gt;</title>",
+			},
+		},
+		{
+			"quotes and line breaks",
+			"",
+			[]PlaylistItem{{"\"A\" 'B'\nC", "https://dl.example/2"}},
+			[]string{"<title>&#34;A&#34; &#39;B&#39;&#xA;C</title>"},
+		},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		if err := WritePlaylist(&b, "xspf", tt.title, tt.items); err != nil {
+			t.Fatal(err)
+		}
+		out := b.String()
+		if !strings.HasPrefix(out, xml.Header+"<playlist version=\"1\" xmlns=\"http://xspf.org/ns/0/\">") {
+			t.Errorf("%s: playlist does not start with the XSPF header:\n%s", tt.name, out)
+		}
+		for _, s := range tt.want {
+			if !strings.Contains(out, s) {
+				t.Errorf("%s: playlist does not contain %s:\n%s", tt.name, s, out)
+			}
+		}
+
+		// The playlist reads back as it was written
+		var got xspfPlaylist
+		if err := xml.Unmarshal([]byte(out), &got); err != nil {
+			t.Fatalf("%s: invalid XML: %v", tt.name, err)
+		}
+		if got.Title != tt.title || len(got.Tracks) != len(tt.items) {
+			t.Fatalf("%s: read back %+v", tt.name, got)
+		}
+		for i, item := range tt.items {
+			if got.Tracks[i].Title != item.Title || got.Tracks[i].Location != item.URL {
+				t.Errorf("%s: track %d reads back as %+v, want %+v", tt.name, i, got.Tracks[i], item)
+			}
+		}
+	}
+}
+
+func TestWritePlaylistUnknownFormat(t *testing.T) {
+	var b strings.Builder
+	if err := WritePlaylist(&b, "pls", "", nil); err == nil || !strings.Contains(err.Error(), "m3u or xspf") {
+		t.Errorf("WritePlaylist(pls) error = %v, want one listing the formats", err)
+	}
+	if b.Len() != 0 {
+		t.Errorf("WritePlaylist(pls) wrote %q", b.String())
+	}
+}
@@ -0,0 +1,245 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"seedr/pkg/seedr"
+)
+
+// DefaultProfile is the profile used when none is selected. Its state lives directly in the
+// base directory, where it was kept before profiles existed.
+const DefaultProfile = "default"
+
+// SelectedProfile is the profile chosen with --profile. When empty, SEEDR_PROFILE and then the
+// profile set with `seedr profile use` are consulted.
+var SelectedProfile string
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// ActiveProfile returns the name of the profile commands operate on.
+func ActiveProfile() string {
+	if SelectedProfile != "" {
+		return SelectedProfile
+	}
+	if env := os.Getenv("SEEDR_PROFILE"); env != "" {
+		return env
+	}
+	if name, err := CurrentProfile(); err == nil && name != "" {
+		return name
+	}
+	return DefaultProfile
+}
+
+// CurrentProfile returns the profile set with `seedr profile use`, or DefaultProfile if none is set.
+func CurrentProfile() (string, error) {
+	path, err := currentProfilePath()
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return DefaultProfile, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading current profile: %w", err)
+	}
+	name := strings.TrimSpace(string(data))
+	if name == "" {
+		return DefaultProfile, nil
+	}
+	return name, nil
+}
+
+// ValidateProfileName checks that name can be used as a profile directory.
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name '%s': use letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+// ProfileDir returns the directory holding a profile's token and other state, without creating it.
+func ProfileDir(name string) (string, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+	base, err := baseDir()
+	if err != nil {
+		return "", err
+	}
+	if name == DefaultProfile {
+		return base, nil
+	}
+	return filepath.Join(base, "profiles", name), nil
+}
+
+// ProfileExists reports whether a profile has been added. The default profile always exists.
+func ProfileExists(name string) (bool, error) {
+	if name == DefaultProfile {
+		return true, nil
+	}
+	dir, err := ProfileDir(name)
+	if err != nil {
+		return false, err
+	}
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+// ProfileLoggedIn reports whether a profile has a saved token.
+func ProfileLoggedIn(name string) bool {
+	dir, err := ProfileDir(name)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(filepath.Join(dir, tokenFileName))
+	return err == nil
+}
+
+// ListProfiles returns the default profile followed by the added profiles in alphabetical order.
+func ListProfiles() ([]string, error) {
+	base, err := baseDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(base, "profiles"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error listing profiles: %w", err)
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() && ValidateProfileName(e.Name()) == nil && e.Name() != DefaultProfile {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return append([]string{DefaultProfile}, names...), nil
+}
+
+// AddProfile creates a new, logged-out profile.
+func AddProfile(name string) error {
+	exists, err := ProfileExists(name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("profile '%s' already exists", name)
+	}
+	dir, err := ProfileDir(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("could not create %s: %w", dir, err)
+	}
+	return nil
+}
+
+// UseProfile makes name the profile used when neither --profile nor SEEDR_PROFILE is given.
+func UseProfile(name string) error {
+	exists, err := ProfileExists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("no profile named '%s'", name)
+	}
+	path, err := currentProfilePath()
+	if err != nil {
+		return err
+	}
+	if name == DefaultProfile {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error resetting current profile: %w", err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(name+"\n"), 0600); err != nil {
+		return fmt.Errorf("error saving current profile: %w", err)
+	}
+	return nil
+}
+
+// RemoveProfile deletes a profile with its token and state. The default profile cannot be removed;
+// if the removed profile was the current one, the default profile becomes current again.
+func RemoveProfile(name string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("the default profile cannot be removed; use `seedr logout` to delete its token")
+	}
+	exists, err := ProfileExists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("no profile named '%s'", name)
+	}
+	dir, err := ProfileDir(name)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("error removing profile: %w", err)
+	}
+	if current, err := CurrentProfile(); err == nil && current == name {
+		return UseProfile(DefaultProfile)
+	}
+	return nil
+}
+
+// SwitchProfile makes name the active profile for the rest of the process and loads its
+// saved token into Account, closing the previous client. Account is left unchanged on error.
+func SwitchProfile(name string) error {
+	client, err := ProfileClient(name)
+	if err != nil {
+		return err
+	}
+	SetActiveProfile(name, client)
+	return nil
+}
+
+// SetActiveProfile makes name the active profile with client, made by ProfileClient, as Account,
+// and closes the previous client. Callers running concurrently with other commands, such as the
+// TUI, build the client in the background and call this from the goroutine that owns the globals.
+func SetActiveProfile(name string, client *seedr.Client) {
+	previous := Account
+	SelectedProfile, Account = name, client
+	if previous != nil && previous != client {
+		previous.Close()
+	}
+}
+
+// ProfileClient returns a client for a profile's saved token without changing the active
+// profile, for commands that work with two accounts at once.
+func ProfileClient(name string) (*seedr.Client, error) {
+	exists, err := ProfileExists(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("no profile named '%s'", name)
+	}
+	dir, err := ProfileDir(name)
+	if err != nil {
+		return nil, err
+	}
+	return loadClient(filepath.Join(dir, tokenFileName))
+}
+
+func currentProfilePath() (string, error) {
+	base, err := baseDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "current-profile"), nil
+}
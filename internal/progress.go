@@ -0,0 +1,58 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"seedr/pkg/seedr"
+)
+
+// RecentVideo is a video that has been partly watched.
+type RecentVideo struct {
+	Path     string // Path from the account root, e.g. "/Movies/x.mkv"
+	File     seedr.File
+	Position time.Duration
+}
+
+// RecentVideos walks the whole account and returns the videos with a saved playback position,
+// most recently updated first.
+func RecentVideos(ctx context.Context, client *seedr.Client) ([]RecentVideo, error) {
+	var videos []RecentVideo
+	err := client.Walk(ctx, "0", func(p string, folder *seedr.Folder, file *seedr.File) error {
+		if file == nil || !file.PlayVideo {
+			return nil
+		}
+		if pos := file.Progress(); pos > 0 {
+			videos = append(videos, RecentVideo{Path: "/" + p, File: *file, Position: pos})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(videos, func(i, j int) bool {
+		a, b := videos[i].File.LastUpdate, videos[j].File.LastUpdate
+		return a != nil && (b == nil || a.After(*b))
+	})
+	return videos, nil
+}
+
+// FormatPosition formats a playback position as "h:mm:ss", or "m:ss" below an hour.
+func FormatPosition(d time.Duration) string {
+	s := int(d.Seconds())
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
+// WatchedPosition returns the position to save for a video stopped at position: 0 once it was
+// watched to the end (the last 5% or minute), so it no longer shows as in progress.
+func WatchedPosition(position, duration time.Duration) time.Duration {
+	if duration > 0 && (duration-position < duration/20 || duration-position < time.Minute) {
+		return 0
+	}
+	return position
+}
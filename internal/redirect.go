@@ -0,0 +1,238 @@
+package internal
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"seedr/pkg/seedr"
+)
+
+// Redirector is an HTTP handler giving files stable URLs. It answers /f/<folder_file_id> and
+// /p/<path> with a redirect to a freshly fetched download URL, so playlists, bookmarks and .strm
+// files keep working after the signed URLs expire. Download URLs and folder listings are cached
+// for TTL.
+type Redirector struct {
+	client *seedr.Client
+	ttl    time.Duration
+	token  string
+	// Log, if set, is called with a line describing every request.
+	Log func(format string, v ...any)
+
+	mu        sync.Mutex
+	urls      map[string]cachedValue // Download URLs by file ID
+	listings  map[string]cachedValue // Folder listings by folder ID
+	lastPrune time.Time
+	now       func() time.Time
+}
+
+type cachedValue struct {
+	value   any
+	expires time.Time
+}
+
+// errNotFound is returned when a path does not name a file.
+var errNotFound = errors.New("not found")
+
+// NewRedirector returns a Redirector fetching URLs with client. If token is not empty, requests
+// must carry it as "Authorization: Bearer <token>" or, for players that can't send headers, as the
+// token query parameter.
+func NewRedirector(client *seedr.Client, ttl time.Duration, token string) *Redirector {
+	return &Redirector{
+		client:   client,
+		ttl:      ttl,
+		token:    token,
+		urls:     make(map[string]cachedValue),
+		listings: make(map[string]cachedValue),
+		now:      time.Now,
+	}
+}
+
+// RedirectURL returns the stable URL of a file served by a Redirector at base, e.g.
+// "http://127.0.0.1:8080".
+func RedirectURL(base, fileID, token string) string {
+	u := strings.TrimRight(base, "/") + "/f/" + url.PathEscape(fileID)
+	if token != "" {
+		u += "?token=" + url.QueryEscape(token)
+	}
+	return u
+}
+
+func (r *Redirector) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	status, detail := r.serve(w, req)
+	if r.Log != nil {
+		r.Log("%s %s %s -> %d %s", req.RemoteAddr, req.Method, req.URL.Path, status, detail)
+	}
+}
+
+// serve handles a request and returns the status code and a note for the log.
+func (r *Redirector) serve(w http.ResponseWriter, req *http.Request) (int, string) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return http.StatusMethodNotAllowed, ""
+	}
+	if !r.authorized(req) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="seedr"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return http.StatusUnauthorized, ""
+	}
+
+	var fileID string
+	switch {
+	case strings.HasPrefix(req.URL.Path, "/f/"):
+		fileID = strings.TrimPrefix(req.URL.Path, "/f/")
+	case strings.HasPrefix(req.URL.Path, "/p/"):
+		var err error
+		if fileID, err = r.resolvePath(req.Context(), strings.TrimPrefix(req.URL.Path, "/p")); err != nil {
+			return r.fail(w, err)
+		}
+	}
+	if fileID == "" || strings.Contains(fileID, "/") {
+		http.NotFound(w, req)
+		return http.StatusNotFound, ""
+	}
+
+	target, cached, err := r.fileURL(req.Context(), fileID)
+	if err != nil {
+		return r.fail(w, err)
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, req, target, http.StatusFound)
+	if cached {
+		return http.StatusFound, "(cached)"
+	}
+	return http.StatusFound, ""
+}
+
+// fail answers with 404 for unknown paths and 502 when the API failed.
+func (r *Redirector) fail(w http.ResponseWriter, err error) (int, string) {
+	if errors.Is(err, errNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return http.StatusNotFound, err.Error()
+	}
+	http.Error(w, "error fetching from Seedr", http.StatusBadGateway)
+	return http.StatusBadGateway, err.Error()
+}
+
+// authorized checks the bearer token, if one is required.
+func (r *Redirector) authorized(req *http.Request) bool {
+	if r.token == "" {
+		return true
+	}
+	given := req.URL.Query().Get("token")
+	if auth := req.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		given = strings.TrimPrefix(auth, "Bearer ")
+	}
+	return subtle.ConstantTimeCompare([]byte(given), []byte(r.token)) == 1
+}
+
+// cached returns a value from a cache if it has not expired.
+func (r *Redirector) cached(cache map[string]cachedValue, key string) (any, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v, ok := cache[key]
+	if !ok || r.now().After(v.expires) {
+		delete(cache, key)
+		return nil, false
+	}
+	return v.value, true
+}
+
+// store caches a value for the TTL. Expired entries are dropped from both caches at most once per
+// TTL, so entries that are never asked for again don't pile up.
+func (r *Redirector) store(cache map[string]cachedValue, key string, value any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	if now.Sub(r.lastPrune) >= r.ttl {
+		for _, c := range []map[string]cachedValue{r.urls, r.listings} {
+			for k, v := range c {
+				if now.After(v.expires) {
+					delete(c, k)
+				}
+			}
+		}
+		r.lastPrune = now
+	}
+	cache[key] = cachedValue{value: value, expires: now.Add(r.ttl)}
+}
+
+// fileURL returns the download URL of a file and whether it came from the cache.
+func (r *Redirector) fileURL(ctx context.Context, fileID string) (string, bool, error) {
+	if v, ok := r.cached(r.urls, fileID); ok {
+		return v.(string), true, nil
+	}
+	fileResult, err := r.client.FetchFile(ctx, fileID)
+	if err != nil {
+		var apiErr *seedr.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return "", false, fmt.Errorf("file %s: %w", fileID, errNotFound)
+		}
+		return "", false, err
+	}
+	if fileResult.URL == "" {
+		return "", false, fmt.Errorf("file %s: %w", fileID, errNotFound)
+	}
+	r.store(r.urls, fileID, fileResult.URL)
+	return fileResult.URL, false, nil
+}
+
+// listing returns the contents of a folder.
+func (r *Redirector) listing(ctx context.Context, folderID string) (*seedr.ListContentsResult, error) {
+	if v, ok := r.cached(r.listings, folderID); ok {
+		return v.(*seedr.ListContentsResult), nil
+	}
+	contents, err := r.client.ListContents(ctx, folderID)
+	if err != nil {
+		return nil, err
+	}
+	r.store(r.listings, folderID, contents)
+	return contents, nil
+}
+
+// resolvePath finds the ID of the file at an absolute path such as "/Movies/x.mkv".
+func (r *Redirector) resolvePath(ctx context.Context, p string) (string, error) {
+	var segments []string
+	for _, s := range strings.Split(p, "/") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+	if len(segments) == 0 {
+		return "", fmt.Errorf("'%s' is not a file: %w", p, errNotFound)
+	}
+
+	folderID := "0"
+	for i, name := range segments {
+		contents, err := r.listing(ctx, folderID)
+		if err != nil {
+			return "", err
+		}
+		if i == len(segments)-1 {
+			for _, f := range contents.Files {
+				if f.Name == name {
+					return fmt.Sprintf("%d", f.FolderFileID), nil
+				}
+			}
+			return "", fmt.Errorf("'%s': %w", p, errNotFound)
+		}
+		found := false
+		for _, f := range contents.Folders {
+			if f.Name == name {
+				folderID, found = fmt.Sprintf("%d", f.ID), true
+				break
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("'%s': %w", p, errNotFound)
+		}
+	}
+	return "", fmt.Errorf("'%s': %w", p, errNotFound)
+}
@@ -0,0 +1,205 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"seedr/pkg/seedr"
+)
+
+// redirectTestAPI is a fake Seedr API holding "Movies/b.mkv" and "My Films/c d.mkv". It counts
+// the calls of each API function, and fails every call while broken is set.
+type redirectTestAPI struct {
+	mu     sync.Mutex
+	calls  map[string]int
+	broken bool
+}
+
+func (api *redirectTestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	fn := r.URL.Query().Get("func")
+	api.mu.Lock()
+	api.calls[fn]++
+	n, broken := api.calls[fn], api.broken
+	api.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if broken {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("{}"))
+		return
+	}
+	var body any
+	switch fn {
+	case "list_contents":
+		switch r.PostForm.Get("content_id") {
+		case "0":
+			body = map[string]any{"result": true, "folders": []any{
+				map[string]any{"id": 1, "name": "Movies"},
+				map[string]any{"id": 2, "name": "My Films"},
+			}}
+		case "1":
+			body = map[string]any{"result": true, "files": []any{map[string]any{"folder_file_id": 11, "name": "b.mkv"}}}
+		case "2":
+			body = map[string]any{"result": true, "files": []any{map[string]any{"folder_file_id": 12, "name": "c d.mkv"}}}
+		}
+	case "fetch_file":
+		if id := r.PostForm.Get("folder_file_id"); id == "11" || id == "12" {
+			// Each URL is different, as signed URLs are, so reuse can be told from a new fetch
+			body = map[string]any{"result": true, "url": fmt.Sprintf("https://dl.example/%s?sig=%d", id, n)}
+		}
+	}
+	if body == nil {
+		w.WriteHeader(http.StatusNotFound)
+		body = map[string]any{"result": false}
+	}
+	json.NewEncoder(w).Encode(body)
+}
+
+func (api *redirectTestAPI) count(fn string) int {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return api.calls[fn]
+}
+
+// newTestRedirector returns a Redirector backed by a fake API and a clock the test can move.
+func newTestRedirector(t *testing.T, token string) (*Redirector, *redirectTestAPI, *time.Time) {
+	t.Helper()
+	api := &redirectTestAPI{calls: make(map[string]int)}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	client := seedr.NewClient(seedr.NewToken("test-token", nil, nil), seedr.WithBaseURL(server.URL))
+	r := NewRedirector(client, time.Minute, token)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+	return r, api, &now
+}
+
+func get(r http.Handler, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRedirectorRedirects(t *testing.T) {
+	r, _, _ := newTestRedirector(t, "")
+	tests := []struct {
+		target, location string
+	}{
+		{"/f/11", "https://dl.example/11?sig=1"},
+		{"/p/Movies/b.mkv", "https://dl.example/11?sig=1"}, // Cached by the request above
+		{"/p/My%20Films/c%20d.mkv", "https://dl.example/12?sig=2"},
+	}
+	for _, tt := range tests {
+		w := get(r, tt.target, nil)
+		if w.Code != http.StatusFound || w.Header().Get("Location") != tt.location {
+			t.Errorf("GET %s = %d to %q, want 302 to %q", tt.target, w.Code, w.Header().Get("Location"), tt.location)
+		}
+		if cc := w.Header().Get("Cache-Control"); cc != "no-store" {
+			t.Errorf("GET %s: Cache-Control = %q, want no-store", tt.target, cc)
+		}
+	}
+}
+
+func TestRedirectorNotFound(t *testing.T) {
+	r, _, _ := newTestRedirector(t, "")
+	for _, target := range []string{"/", "/x", "/f/", "/f/99", "/f/11/x", "/p/", "/p/Movies", "/p/Movies/missing.mkv", "/p/Missing/b.mkv", "/p/Movies/b.mkv/x"} {
+		if w := get(r, target, nil); w.Code != http.StatusNotFound {
+			t.Errorf("GET %s = %d, want 404", target, w.Code)
+		}
+	}
+}
+
+func TestRedirectorErrors(t *testing.T) {
+	r, api, _ := newTestRedirector(t, "")
+	api.broken = true
+	if w := get(r, "/p/Movies/b.mkv", nil); w.Code != http.StatusBadGateway {
+		t.Errorf("GET with the API failing = %d, want 502", w.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/f/11", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST = %d, want 405", w.Code)
+	}
+}
+
+func TestRedirectorToken(t *testing.T) {
+	r, api, _ := newTestRedirector(t, "secret")
+	tests := []struct {
+		name   string
+		target string
+		auth   string
+		want   int
+	}{
+		{"missing", "/f/11", "", http.StatusUnauthorized},
+		{"wrong bearer", "/f/11", "Bearer wrong", http.StatusUnauthorized},
+		{"wrong query", "/f/11?token=wrong", "", http.StatusUnauthorized},
+		{"other scheme", "/f/11", "Basic secret", http.StatusUnauthorized},
+		{"bearer", "/f/11", "Bearer secret", http.StatusFound},
+		{"query", "/f/11?token=secret", "", http.StatusFound},
+		{"wrong bearer overrides query", "/f/11?token=secret", "Bearer wrong", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		header := http.Header{}
+		if tt.auth != "" {
+			header.Set("Authorization", tt.auth)
+		}
+		w := get(r, tt.target, header)
+		if w.Code != tt.want {
+			t.Errorf("%s: GET %s = %d, want %d", tt.name, tt.target, w.Code, tt.want)
+		}
+		if w.Code == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("%s: 401 without WWW-Authenticate", tt.name)
+		}
+	}
+	if n := api.count("fetch_file"); n != 1 {
+		t.Errorf("fetched %d download URLs, want 1: unauthorized requests must not reach the API", n)
+	}
+}
+
+func TestRedirectorCache(t *testing.T) {
+	r, api, now := newTestRedirector(t, "")
+
+	first := get(r, "/p/Movies/b.mkv", nil).Header().Get("Location")
+	*now = now.Add(30 * time.Second)
+	if again := get(r, "/p/Movies/b.mkv", nil).Header().Get("Location"); again != first {
+		t.Errorf("within the TTL: redirected to %q, want the cached %q", again, first)
+	}
+	if lists, fetches := api.count("list_contents"), api.count("fetch_file"); lists != 2 || fetches != 1 {
+		t.Errorf("within the TTL: %d listings and %d fetches, want 2 and 1", lists, fetches)
+	}
+
+	*now = now.Add(time.Minute)
+	if expired := get(r, "/p/Movies/b.mkv", nil).Header().Get("Location"); expired == first {
+		t.Errorf("after the TTL: redirected to the expired %q", expired)
+	}
+	if lists, fetches := api.count("list_contents"), api.count("fetch_file"); lists != 4 || fetches != 2 {
+		t.Errorf("after the TTL: %d listings and %d fetches, want 4 and 2", lists, fetches)
+	}
+}
+
+func TestRedirectorPrune(t *testing.T) {
+	r, _, now := newTestRedirector(t, "")
+	get(r, "/p/Movies/b.mkv", nil)
+	if len(r.urls) != 1 || len(r.listings) != 2 {
+		t.Fatalf("cached %d URLs and %d listings, want 1 and 2", len(r.urls), len(r.listings))
+	}
+
+	*now = now.Add(2 * time.Minute)
+	get(r, "/f/12", nil)
+	if _, ok := r.urls["11"]; ok || len(r.urls) != 1 || len(r.listings) != 0 {
+		t.Errorf("after the TTL: cached URLs %v and %d listings, want only the new URL", r.urls, len(r.listings))
+	}
+}
@@ -1,11 +1,11 @@
 package internal
 
 import (
-	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
+	"time"
 
 	"seedr/pkg/seedr"
 )
@@ -22,93 +22,157 @@ type SeedrListContentsResult = seedr.ListContentsResult
 // SeedrUserSettings is an alias for seedr.UserSettings
 type SeedrUserSettings = seedr.UserSettings
 
+// SeedrFolder is an alias for seedr.Folder
+type SeedrFolder = seedr.Folder
+
+// SeedrFile is an alias for seedr.File
+type SeedrFile = seedr.File
+
+// SeedrTorrent is an alias for seedr.Torrent
+type SeedrTorrent = seedr.Torrent
+
 // DebugLog is a package-level variable to hold the debug logging function.
 // It is meant to be set by an external package (e.g., cmd) to route debug messages.
 // By default, it's a no-op function.
 var DebugLog = func(format string, a ...interface{}) {}
 
 
-// onTokenRefresh is a global callback function for token refreshes.
-// It saves the new token to file.
-var onTokenRefresh = func(newToken *seedr.Token) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error getting user home directory for token refresh: %v\n", err)
-		return
+// onTokenRefresh returns the callback for token refreshes of a client whose token is saved at
+// tokenLocation. It saves the new token to that file, so a refresh keeps going to the profile
+// the client was created for even after switching profiles.
+func onTokenRefresh(tokenLocation string) func(newToken *seedr.Token) {
+	return func(newToken *seedr.Token) {
+		jsonStr, err := newToken.ToJSON()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving refreshed token to JSON: %v\n", err)
+			return
+		}
+		if err := os.WriteFile(tokenLocation, []byte(jsonStr), 0600); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing refreshed token to file: %v\n", err)
+		} else {
+			fmt.Println("Token refreshed and saved.")
+		}
+	}
+}
+
+// ErrNotLoggedIn is returned by LoadSavedAccount when no token has been saved.
+var ErrNotLoggedIn = errors.New("not logged in; run `seedr login`")
+
+// FetchSeedrAccessToken loads the saved token, or logs in with the device flow if there is none.
+func FetchSeedrAccessToken() error {
+	err := LoadSavedAccount()
+	if errors.Is(err, ErrNotLoggedIn) {
+		// No token file, perform device authentication
+		DebugLog("No token found. Initiating device authentication flow...")
+		return LoginWithDeviceCode(context.Background())
 	}
-	seedrFolder := filepath.Join(homeDir, ".cache", "seedr")
-	tokenLocation := filepath.Join(seedrFolder, "token.txt")
+	return err
+}
 
-	jsonStr, err := newToken.ToJSON()
+// LoadSavedAccount sets Account from the saved token. It returns ErrNotLoggedIn if there is none.
+func LoadSavedAccount() error {
+	tokenLocation, err := TokenPath()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error saving refreshed token to JSON: %v\n", err)
-		return
+		return err
 	}
-	if err := os.WriteFile(tokenLocation, []byte(jsonStr), 0600); err != nil {
-		fmt.Fprintf(os.Stderr, "Error writing refreshed token to file: %v\n", err)
-	} else {
-		fmt.Println("Token refreshed and saved.")
+	client, err := loadClient(tokenLocation)
+	if err != nil {
+		return err
 	}
+	Account = client // Set the global client
+	return nil
 }
 
-// FetchSeedrAccessToken handles token retrieval and persistence.
-func FetchSeedrAccessToken() error {
-	homeDir, err := os.UserHomeDir()
+// loadClient creates a client from the token saved at tokenLocation. It returns ErrNotLoggedIn if there is none.
+func loadClient(tokenLocation string) (*seedr.Client, error) {
+	tokenBytes, err := os.ReadFile(tokenLocation)
+	if os.IsNotExist(err) {
+		return nil, ErrNotLoggedIn
+	}
 	if err != nil {
-		return fmt.Errorf("could not get user home directory: %w", err)
+		return nil, fmt.Errorf("error reading token file: %w", err)
 	}
-	seedrFolder := filepath.Join(homeDir, ".cache", "seedr")
-	if _, err := os.Stat(seedrFolder); os.IsNotExist(err) {
-		os.MkdirAll(seedrFolder, 0755)
+	DebugLog("Token file found. Loading existing token...")
+	token, err := seedr.TokenFromJSON(string(tokenBytes))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing token from JSON: %w", err)
 	}
-	tokenLocation := filepath.Join(seedrFolder, "token.txt")
+	// Create client from existing token
+	return seedr.NewClient(token, seedr.WithTokenRefreshCallback(onTokenRefresh(tokenLocation))), nil
+}
 
-	ctx := context.Background()
+// RemoveSavedToken deletes the saved token. It is not an error if there is none.
+func RemoveSavedToken() error {
+	tokenLocation, err := TokenPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(tokenLocation); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing token file: %w", err)
+	}
+	return nil
+}
 
-	if _, err := os.Stat(tokenLocation); os.IsNotExist(err) {
-		// No token file, perform device authentication
-		DebugLog("No token found. Initiating device authentication flow...")
-	
-	
-codes, err := seedr.GetDeviceCode(ctx)
-		if err != nil {
-			return fmt.Errorf("error getting device code: %w", err)
-		}
+// LoginWithDeviceCode runs the device authorization flow. It prints the code to enter on the
+// Seedr website, then polls until the device is authorized or the code expires, without
+// reading from stdin. On success the token is saved and Account is set.
+func LoginWithDeviceCode(ctx context.Context) error {
+	tokenLocation, err := TokenPath()
+	if err != nil {
+		return err
+	}
+	codes, err := seedr.GetDeviceCode(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting device code: %w", err)
+	}
 
-		fmt.Printf("Please go to %s and enter the code: %s\n", codes.VerificationURL, codes.UserCode)
-		fmt.Print("Press Enter after authorizing the device.")
-		bufio.NewReader(os.Stdin).ReadBytes('\n') // Wait for user to press Enter
+	fmt.Printf("Please go to %s and enter the code: %s\n", codes.VerificationURL, codes.UserCode)
+	onPending := func(remaining time.Duration) {
+		fmt.Fprintf(os.Stderr, "\rWaiting for authorization... %s left ", remaining.Round(time.Second))
+	}
+	client, err := seedr.PollDeviceCode(ctx, codes, onPending, seedr.WithTokenRefreshCallback(onTokenRefresh(tokenLocation)))
+	fmt.Fprintln(os.Stderr)
+	if errors.Is(err, seedr.ErrDeviceCodeExpired) {
+		return fmt.Errorf("timed out after %ds waiting for the device to be authorized; run the command again to get a new code", codes.ExpiresIn)
+	}
+	if err != nil {
+		return fmt.Errorf("error creating client from device code: %w", err)
+	}
+	return setAccount(client)
+}
 
-		client, err := seedr.FromDeviceCode(ctx, codes.DeviceCode, seedr.WithTokenRefreshCallback(onTokenRefresh))
-		if err != nil {
-			return fmt.Errorf("error creating client from device code: %w", err)
-		}
-		Account = client // Set the global client
-		tokenJson, err := Account.Token().ToJSON() // Use Token() accessor
-		if err != nil {
-			return fmt.Errorf("error converting new token to JSON: %w", err)
-		}
-		fmt.Printf("Authorization Successful. Token: %s\n", Account.Token().String()) // Use Token() accessor
+// LoginWithPassword logs in with a username and password. On success the token is saved and Account is set.
+func LoginWithPassword(ctx context.Context, username, password string) error {
+	tokenLocation, err := TokenPath()
+	if err != nil {
+		return err
+	}
+	client, err := seedr.FromPassword(ctx, username, password, seedr.WithTokenRefreshCallback(onTokenRefresh(tokenLocation)))
+	if err != nil {
+		return fmt.Errorf("error logging in as %s: %w", username, err)
+	}
+	return setAccount(client)
+}
 
-		if err := os.WriteFile(tokenLocation, []byte(tokenJson), 0600); err != nil {
-			return fmt.Errorf("error writing token to file: %w", err)
-		}
-		return nil
+// setAccount makes client the global Account and saves its token.
+func setAccount(client *seedr.Client) error {
+	Account = client // Set the global client
+	fmt.Printf("Authorization Successful. Token: %s\n", Account.Token().String()) // Use Token() accessor
+	return SaveToken(Account.Token())
+}
 
-	} else {
-		// Token file exists, load it
-		DebugLog("Token file found. Loading existing token...")
-		tokenBytes, err := os.ReadFile(tokenLocation)
-		if err != nil {
-			return fmt.Errorf("error reading token file: %w", err)
-		}
-		token, err := seedr.TokenFromJSON(string(tokenBytes))
-		if err != nil {
-			return fmt.Errorf("error parsing token from JSON: %w", err)
-		}
-		// Create client from existing token
-		client := seedr.NewClient(token, seedr.WithTokenRefreshCallback(onTokenRefresh))
-		Account = client // Set the global client
-		return nil
+// SaveToken writes token to the token file, replacing any saved token.
+func SaveToken(token *seedr.Token) error {
+	tokenLocation, err := TokenPath()
+	if err != nil {
+		return err
+	}
+	tokenJson, err := token.ToJSON()
+	if err != nil {
+		return fmt.Errorf("error converting new token to JSON: %w", err)
+	}
+	if err := os.WriteFile(tokenLocation, []byte(tokenJson), 0600); err != nil {
+		return fmt.Errorf("error writing token to file: %w", err)
 	}
+	return nil
 }
@@ -1,14 +1,11 @@
 package internal
 
 import (
-	"bytes"
 	"fmt"
 	"io"
 	"log"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"runtime"
 	"sync"
 	"time" // Added for unique filenames
 	
@@ -22,26 +19,6 @@ func HumanReadableBytes(byteCount int) string {
 	return humanize.Bytes(uint64(byteCount))
 }
 
-// CopyToClipboard copies the given text to the system clipboard.
-// It uses "xclip" for Linux and "pbcopy" for macOS.
-func CopyToClipboard(text string) error {
-	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "linux":
-		cmd = exec.Command("xclip", "-selection", "clipboard")
-	case "darwin":
-		cmd = exec.Command("pbcopy")
-	default:
-		return fmt.Errorf("unsupported operating system for clipboard operations: %s", runtime.GOOS)
-	}
-
-	cmd.Stdin = bytes.NewReader([]byte(text))
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to copy to clipboard: %w", err)
-	}
-	return nil
-}
-
 // Logger provides a flexible logging mechanism.
 type Logger struct {
 	fileLogger    *log.Logger
@@ -124,9 +101,9 @@ func NewLogger(debugMode, isTUI bool) *Logger {
 
 	if l.logToFile {
 
-		logDirPath := filepath.Join(os.Getenv("HOME"), ".local", "share", "logs")
+		logDirPath, err := LogDir()
 
-		if err := os.MkdirAll(logDirPath, 0755); err != nil {
+		if err != nil {
 
 			log.Printf("Failed to create log directory: %v", err)
 
@@ -203,7 +180,12 @@ func (l *Logger) LogRequestResponse(requestInfo, responseInfo string) {
 		l.mu.Lock()
 		defer l.mu.Unlock()
 
-		logDirPath := filepath.Join(os.Getenv("HOME"), ".local", "share", "logs", "seedr", requestResponseLogDir)
+		logDir, err := LogDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: Failed to create log directory: %v\n", err)
+			return
+		}
+		logDirPath := filepath.Join(logDir, requestResponseLogDir)
 		if err := os.MkdirAll(logDirPath, 0755); err != nil {
 			fmt.Fprintf(os.Stderr, "ERROR: Failed to create request/response log directory %s: %v\n", logDirPath, err)
 			return
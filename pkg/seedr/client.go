@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -23,7 +24,8 @@ type Client struct {
 	httpClient *http.Client
 	token      *Token
 	onTokenRefresh OnTokenRefreshCallback
-	mu         sync.Mutex // Mutex for protecting client-wide state, especially during token refresh
+	mu         sync.Mutex // Serializes token refreshes; the token itself guards its fields
+	baseURL    string     // Replaces SiteURL in request URLs when set
 
 	// Stores whether the client manages its own http.Client lifecycle.
 	// If true, httpClient.CloseIdleConnections() will be called on Client.Close().
@@ -73,6 +75,14 @@ func WithProxy(proxyURL *url.URL) ClientOption {
 	}
 }
 
+// WithBaseURL sends the client's requests to another server than SiteURL, keeping the paths of
+// the API endpoints, e.g. to a test server.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
 // WithTokenRefreshCallback sets the callback function for token refreshes.
 func WithTokenRefreshCallback(callback OnTokenRefreshCallback) ClientOption {
 	return func(c *Client) {
@@ -114,6 +124,14 @@ func (c *Client) Token() *Token {
 	return c.token
 }
 
+// endpoint returns the URL of an API endpoint, on the server set with WithBaseURL if any.
+func (c *Client) endpoint(rawURL string) string {
+	if c.baseURL == "" {
+		return rawURL
+	}
+	return c.baseURL + strings.TrimPrefix(rawURL, SiteURL)
+}
+
 // makeHTTPRequest performs the raw HTTP request and handles low-level network or HTTP status errors.
 func (c *Client) makeHTTPRequest(
 	ctx context.Context,
@@ -237,10 +255,7 @@ func (c *Client) apiRequest(
 	extraParams map[string]string, // For URL params not part of the 'data' payload
 	rawURL string, // Optional: override default URL
 ) (map[string]interface{}, error) {
-	c.mu.Lock() // Protect client state during token handling
-	defer c.mu.Unlock()
-
-	requestURL := ResourceURL
+	requestURL := c.endpoint(ResourceURL)
 	if rawURL != "" {
 		requestURL = rawURL
 	}
@@ -256,15 +271,20 @@ func (c *Client) apiRequest(
 	}
 
 
-	// First attempt
+	// First attempt. Requests run concurrently; only the token refresh below is serialized.
 	response, err := c.makeHTTPRequest(ctx, method, requestURL, params, data, files)
 	if err != nil {
 		if apiErr, ok := err.(*APIError); ok {
 			if apiErr.ErrorType == "expired_token" {
-				// Token expired, attempt refresh
-				if refreshErr := c.refreshAccessToken(ctx); refreshErr != nil {
-					return nil, refreshErr // Refresh failed
+				// Token expired, attempt refresh unless a concurrent request already did
+				c.mu.Lock()
+				if c.token.GetAccessToken() == params["access_token"] {
+					if refreshErr := c.refreshAccessToken(ctx); refreshErr != nil {
+						c.mu.Unlock()
+						return nil, refreshErr // Refresh failed
+					}
 				}
+				c.mu.Unlock()
 				// Retry with new access token
 				params["access_token"] = c.token.GetAccessToken()
 				response, err = c.makeHTTPRequest(ctx, method, requestURL, params, data, files)
@@ -301,10 +321,10 @@ func (c *Client) refreshAccessToken(ctx context.Context) error {
 
 	if refreshToken != nil && *refreshToken != "" {
 		payload := PrepareRefreshTokenPayload(*refreshToken)
-		response, err = c.makeHTTPRequest(ctx, http.MethodPost, TokenURL, nil, payload, nil)
+		response, err = c.makeHTTPRequest(ctx, http.MethodPost, c.endpoint(TokenURL), nil, payload, nil)
 	} else if deviceCode != nil && *deviceCode != "" {
 		params := PrepareDeviceCodeParams(*deviceCode)
-		response, err = c.makeHTTPRequest(ctx, http.MethodGet, DeviceAuthorizeURL, params, nil, nil)
+		response, err = c.makeHTTPRequest(ctx, http.MethodGet, c.endpoint(DeviceAuthorizeURL), params, nil, nil)
 	} else {
 		return NewAuthenticationError("Session expired. No refresh token or device code available.", 0, nil)
 	}
@@ -350,6 +370,12 @@ func initializeClient(
 		return nil, err
 	}
 
+	accessToken, ok := response_data["access_token"].(string)
+	if !ok || accessToken == "" {
+		body, _ := json.Marshal(response_data)
+		return nil, NewAuthenticationError("The response did not contain an access token", 0, body)
+	}
+
 	tokenExtras := tokenExtrasCallable(response_data)
 	
 	var refreshToken *string
@@ -358,7 +384,7 @@ func initializeClient(
 	}
 
 	token := NewToken(
-		response_data["access_token"].(string),
+		accessToken,
 		refreshToken,
 		nil, // Device code is handled by tokenExtrasCallable if applicable
 	)
@@ -369,7 +395,9 @@ func initializeClient(
 
 	// Create the actual client with the obtained token
 	client := NewClient(token, opts...)
-	client.onTokenRefresh = onTokenRefresh // Ensure the callback is set on the final client
+	if onTokenRefresh != nil {
+		client.onTokenRefresh = onTokenRefresh // Don't clobber a callback set through opts
+	}
 	return client, nil
 }
 
@@ -415,6 +443,49 @@ func FromDeviceCode(ctx context.Context, deviceCode string, opts ...ClientOption
 	return initializeClient(ctx, authCallable, tokenExtrasCallable, nil, opts...)
 }
 
+// ErrDeviceCodeExpired is returned by PollDeviceCode when the device was not authorized in time.
+var ErrDeviceCodeExpired = errors.New("the device code expired before the device was authorized")
+
+// PollDeviceCode waits for the user to authorize a device code, polling DeviceAuthorizeURL every
+// code.Interval seconds until code.ExpiresIn runs out. onPending, if set, is called after every
+// unsuccessful attempt with the time left. Transient errors keep polling; a denied authorization
+// stops it.
+func PollDeviceCode(ctx context.Context, code *DeviceCode, onPending func(remaining time.Duration), opts ...ClientOption) (*Client, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	expiresIn := time.Duration(code.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = 10 * time.Minute
+	}
+	deadline := time.Now().Add(expiresIn)
+
+	for {
+		client, err := FromDeviceCode(ctx, code.DeviceCode, opts...)
+		if err == nil {
+			return client, nil
+		}
+		var authErr *AuthenticationError
+		if errors.As(err, &authErr) && (authErr.ErrorType == "access_denied" || authErr.ErrorType == "expired_token") {
+			return nil, err
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, ErrDeviceCodeExpired
+		}
+		if onPending != nil {
+			onPending(remaining)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(min(interval, remaining)):
+		}
+	}
+}
+
 // FromRefreshToken creates a new client by using an existing refresh token.
 func FromRefreshToken(ctx context.Context, refreshToken string, opts ...ClientOption) (*Client, error) {
 	authCallable := func(httpClient *http.Client) (map[string]interface{}, error) {
@@ -547,15 +618,15 @@ func (c *Client) CreateArchive(ctx context.Context, folderID string) (*CreateArc
 	return &car, nil
 }
 
-// SearchFiles searches for files.
-func (c *Client) SearchFiles(ctx context.Context, query string) (*Folder, error) {
+// SearchFiles searches for files and folders matching query.
+func (c *Client) SearchFiles(ctx context.Context, query string) (*SearchFilesResult, error) {
 	data := PrepareSearchFilesPayload(query)
 	response_data, err := c.apiRequest(ctx, http.MethodPost, "search_files", data, nil, nil, "")
 	if err != nil {
 		return nil, err
 	}
-	folder := NewFolderFromMap(response_data)
-	return &folder, nil
+	sfr := NewSearchFilesResultFromMap(response_data)
+	return &sfr, nil
 }
 
 // AddFolder adds a folder.
@@ -591,9 +662,32 @@ func (c *Client) RenameFolder(ctx context.Context, folderID, renameTo string) (*
 	return &result, nil
 }
 
+// Move moves files, folders and torrents into the folder identified by destFolderID.
+// All items are sent in a single request.
+func (c *Client) Move(ctx context.Context, items []ItemRef, destFolderID string) (*APIResult, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no items to move")
+	}
+	data := PrepareMovePayload(items, destFolderID)
+	response_data, err := c.apiRequest(ctx, http.MethodPost, "move", data, nil, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	result := NewAPIResultFromMap(response_data)
+	return &result, nil
+}
+
 // deleteAPIItem is a helper for deleting various item types.
 func (c *Client) deleteAPIItem(ctx context.Context, itemType, itemID string) (*APIResult, error) {
-	data := PrepareDeleteItemPayload(itemType, itemID)
+	return c.DeleteItems(ctx, []ItemRef{{Type: itemType, ID: itemID}})
+}
+
+// DeleteItems deletes files, folders and torrents in a single request.
+func (c *Client) DeleteItems(ctx context.Context, items []ItemRef) (*APIResult, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no items to delete")
+	}
+	data := PrepareDeleteItemsPayload(items)
 	response_data, err := c.apiRequest(ctx, http.MethodPost, "delete", data, nil, nil, "")
 	if err != nil {
 		return nil, err
@@ -617,6 +711,15 @@ func (c *Client) DeleteTorrent(ctx context.Context, torrentID string) (*APIResul
 	return c.deleteAPIItem(ctx, "torrent", torrentID)
 }
 
+// GetWishlist retrieves the torrents queued on the wishlist. The API only returns them as part of the settings.
+func (c *Client) GetWishlist(ctx context.Context) ([]WishlistItem, error) {
+	settings, err := c.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return settings.Account.Wishlist, nil
+}
+
 // DeleteWishlist deletes an item from the wishlist.
 func (c *Client) DeleteWishlist(ctx context.Context, wishlistID string) (*APIResult, error) {
 	data := PrepareRemoveWishlistPayload(wishlistID)
@@ -648,6 +751,34 @@ func (c *Client) GetDevices(ctx context.Context) ([]Device, error) {
 	return devices, nil
 }
 
+// RevokeDevice removes a connected device, invalidating the tokens it holds.
+func (c *Client) RevokeDevice(ctx context.Context, clientID string) (*APIResult, error) {
+	data := PrepareRemoveDevicePayload(clientID)
+	response_data, err := c.apiRequest(ctx, http.MethodPost, "remove_device", data, nil, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	result := NewAPIResultFromMap(response_data)
+	return &result, nil
+}
+
+// UpdateSettings changes account settings through user_account_modify.
+// The API accepts one setting per request, so a request is sent for every field set in update;
+// it stops at the first failure.
+func (c *Client) UpdateSettings(ctx context.Context, update SettingsUpdate) error {
+	payloads := settingsUpdatePayloads(update)
+	if len(payloads) == 0 {
+		return fmt.Errorf("no settings to update")
+	}
+	for _, data := range payloads {
+		// apiRequest fails on result=false; a response without a result counts as success
+		if _, err := c.apiRequest(ctx, http.MethodPost, "user_account_modify", data, nil, nil, ""); err != nil {
+			return fmt.Errorf("failed to update %s: %w", data["setting"], err)
+		}
+	}
+	return nil
+}
+
 // ChangeName changes the name of the account.
 func (c *Client) ChangeName(ctx context.Context, name, password string) (*APIResult, error) {
 	data := PrepareChangeNamePayload(name, password)
@@ -0,0 +1,122 @@
+package seedr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestMove(t *testing.T) {
+	type movedItem struct {
+		Type string `json:"type"`
+		ID   int    `json:"id"`
+	}
+	tests := []struct {
+		name  string
+		items []ItemRef
+		want  []movedItem
+	}{
+		{"files", []ItemRef{{Type: "file", ID: "11"}, {Type: "file", ID: "12"}}, []movedItem{{"file", 11}, {"file", 12}}},
+		{"folders", []ItemRef{{Type: "folder", ID: "3"}}, []movedItem{{"folder", 3}}},
+		{"mixed", []ItemRef{{Type: "folder", ID: "3"}, {Type: "file", ID: "11"}, {Type: "torrent", ID: "7"}}, []movedItem{{"folder", 3}, {"file", 11}, {"torrent", 7}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeAPI(t)
+			api.Handle("move", func(url.Values) (int, any) {
+				return http.StatusOK, map[string]any{"result": true}
+			})
+
+			result, err := api.Client().Move(context.Background(), tt.items, "42")
+			if err != nil {
+				t.Fatalf("Move: %v", err)
+			}
+			if !result.Result {
+				t.Errorf("Move result = false, want true")
+			}
+
+			calls := api.Calls()
+			if len(calls) != 1 {
+				t.Fatalf("got %d requests, want a single move request", len(calls))
+			}
+			if got := calls[0].Form.Get("move_to"); got != "42" {
+				t.Errorf("move_to = %q, want \"42\"", got)
+			}
+			var moved []movedItem
+			if err := json.Unmarshal([]byte(calls[0].Form.Get("move_arr")), &moved); err != nil {
+				t.Fatalf("move_arr %q is not a JSON array: %v", calls[0].Form.Get("move_arr"), err)
+			}
+			if !reflect.DeepEqual(moved, tt.want) {
+				t.Errorf("move_arr = %+v, want %+v", moved, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoveErrors(t *testing.T) {
+	api := newFakeAPI(t)
+	client := api.Client()
+	items := []ItemRef{{Type: "file", ID: "11"}}
+
+	if _, err := client.Move(context.Background(), nil, "42"); err == nil {
+		t.Errorf("Move with no items succeeded")
+	}
+	if n := len(api.Calls()); n != 0 {
+		t.Errorf("Move with no items sent %d requests", n)
+	}
+
+	api.Handle("move", func(url.Values) (int, any) {
+		return http.StatusOK, map[string]any{"result": false, "error": "folder_not_found"}
+	})
+	_, err := client.Move(context.Background(), items, "42")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "folder_not_found" {
+		t.Errorf("Move rejected by the API: error = %v, want an APIError with the API's message", err)
+	}
+
+	api.Handle("move", func(url.Values) (int, any) {
+		return http.StatusInternalServerError, map[string]any{}
+	})
+	_, err = client.Move(context.Background(), items, "42")
+	var serverErr *ServerError
+	if !errors.As(err, &serverErr) || serverErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Move on a failing server: error = %v, want a ServerError with status 500", err)
+	}
+}
+
+func TestUpdateSettings(t *testing.T) {
+	on, lang := true, "en"
+	update := SettingsUpdate{AllowRemoteAccess: &on, SiteLanguage: &lang}
+
+	api := newFakeAPI(t)
+	api.Handle("user_account_modify", func(form url.Values) (int, any) {
+		if form.Get("setting") == "site_language" {
+			return http.StatusOK, map[string]any{} // No result field, as some calls answer
+		}
+		return http.StatusOK, map[string]any{"result": true}
+	})
+	if err := api.Client().UpdateSettings(context.Background(), update); err != nil {
+		t.Fatalf("UpdateSettings: %v", err)
+	}
+	var got []string
+	for _, call := range api.Calls() {
+		setting := call.Form.Get("setting")
+		got = append(got, setting+"="+call.Form.Get(setting))
+	}
+	if want := []string{"allow_remote_access=1", "site_language=en"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("settings sent = %q, want %q", got, want)
+	}
+
+	api.Handle("user_account_modify", func(url.Values) (int, any) {
+		return http.StatusOK, map[string]any{"result": false, "error": "invalid_value"}
+	})
+	err := api.Client().UpdateSettings(context.Background(), update)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "invalid_value" {
+		t.Errorf("UpdateSettings rejected by the API: error = %v, want an APIError with the API's message", err)
+	}
+}
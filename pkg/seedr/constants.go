@@ -1,8 +1,9 @@
 package seedr
 
 const (
-	BaseAPIURL       = "https://www.seedr.cc/api"
-	OAuthURL         = "https://www.seedr.cc/oauth_test"
+	SiteURL          = "https://www.seedr.cc"
+	BaseAPIURL       = SiteURL + "/api"
+	OAuthURL         = SiteURL + "/oauth_test"
 	ResourceURL      = OAuthURL + "/resource.php"
 	TokenURL         = OAuthURL + "/token.php"
 	DeviceCodeURL    = BaseAPIURL + "/device/code"
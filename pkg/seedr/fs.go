@@ -0,0 +1,345 @@
+package seedr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FS is a read-only fs.FS over the files of a Seedr account: folders are directories and files
+// are regular files with their size and last update as modification time. Opening a file fetches
+// its download URL, and reading streams the content over HTTP, so FS works with fs.WalkDir,
+// http.FileServerFS and testing/fstest. Every call lists the folders on the path again, so an FS
+// always reflects the current state of the account.
+type FS struct {
+	client *Client
+	ctx    context.Context
+}
+
+var (
+	_ fs.ReadDirFS = (*FS)(nil)
+	_ fs.StatFS    = (*FS)(nil)
+)
+
+// NewFS returns an FS over the account client is logged in to, rooted at the top folder.
+func NewFS(client *Client) *FS {
+	return &FS{client: client, ctx: context.Background()}
+}
+
+// WithContext returns a copy of the FS using ctx for its API requests and downloads.
+func (f *FS) WithContext(ctx context.Context) *FS {
+	return &FS{client: f.client, ctx: ctx}
+}
+
+// fsEntry is a resolved name: exactly one of folder and file is set. The root has a folder with ID 0.
+type fsEntry struct {
+	name   string
+	folder *Folder
+	file   *File
+}
+
+// resolve finds the folder or file at a valid fs path.
+func (f *FS) resolve(op, name string) (fsEntry, error) {
+	if !fs.ValidPath(name) {
+		return fsEntry{}, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	current := fsEntry{name: ".", folder: &Folder{ID: 0}}
+	if name == "." {
+		return current, nil
+	}
+	for _, segment := range strings.Split(name, "/") {
+		if current.folder == nil {
+			return fsEntry{}, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist} // A file has no children
+		}
+		contents, err := f.client.ListContents(f.ctx, fmt.Sprintf("%d", current.folder.ID))
+		if err != nil {
+			return fsEntry{}, &fs.PathError{Op: op, Path: name, Err: err}
+		}
+		next, ok := findEntry(contents, segment)
+		if !ok {
+			return fsEntry{}, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		current = next
+	}
+	return current, nil
+}
+
+// findEntry looks a name up among a folder's subfolders and files, folders first.
+func findEntry(contents *ListContentsResult, name string) (fsEntry, bool) {
+	for i := range contents.Folders {
+		if folderName(&contents.Folders[i]) == name {
+			return fsEntry{name: name, folder: &contents.Folders[i]}, true
+		}
+	}
+	for i := range contents.Files {
+		if contents.Files[i].Name == name {
+			return fsEntry{name: name, file: &contents.Files[i]}, true
+		}
+	}
+	return fsEntry{}, false
+}
+
+// folderName returns the last element of a folder's name, which some listings report as a path.
+func folderName(folder *Folder) string {
+	return path.Base(folder.Name)
+}
+
+// readDir lists a folder as directory entries sorted by name. Names that can't be fs path
+// elements, and repeated names, are left out so every entry can be opened.
+func (f *FS) readDir(op, name string, folder *Folder) ([]fs.DirEntry, error) {
+	contents, err := f.client.ListContents(f.ctx, fmt.Sprintf("%d", folder.ID))
+	if err != nil {
+		return nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	seen := make(map[string]bool)
+	var entries []fs.DirEntry
+	add := func(e fsEntry) {
+		if seen[e.name] || e.name == "." || e.name == ".." || !fs.ValidPath(e.name) || strings.Contains(e.name, "/") {
+			return
+		}
+		seen[e.name] = true
+		entries = append(entries, newFileInfo(e))
+	}
+	for i := range contents.Folders {
+		add(fsEntry{name: folderName(&contents.Folders[i]), folder: &contents.Folders[i]})
+	}
+	for i := range contents.Files {
+		add(fsEntry{name: contents.Files[i].Name, file: &contents.Files[i]})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// Open opens the named file or directory. Files are read by streaming their download URL.
+func (f *FS) Open(name string) (fs.File, error) {
+	e, err := f.resolve("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if e.folder != nil {
+		return &fsDir{fsys: f, path: name, folder: e.folder, info: newFileInfo(e)}, nil
+	}
+	fileResult, err := f.client.FetchFile(f.ctx, fmt.Sprintf("%d", e.file.FolderFileID))
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	if fileResult.URL == "" {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: errors.New("no download URL")}
+	}
+	return &fsFile{fsys: f, path: name, info: newFileInfo(e), url: fileResult.URL}, nil
+}
+
+// ReadDir lists the named directory, sorted by name.
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	e, err := f.resolve("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	if e.folder == nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	return f.readDir("readdir", name, e.folder)
+}
+
+// Stat describes the named file or directory without fetching its download URL.
+func (f *FS) Stat(name string) (fs.FileInfo, error) {
+	e, err := f.resolve("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return newFileInfo(e), nil
+}
+
+// fileInfo describes a folder or file; it serves as both fs.FileInfo and fs.DirEntry.
+type fileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	dir     bool
+}
+
+func newFileInfo(e fsEntry) *fileInfo {
+	info := &fileInfo{name: e.name}
+	var lastUpdate *time.Time
+	if e.folder != nil {
+		info.dir = true
+		lastUpdate = e.folder.LastUpdate
+	} else {
+		info.size = int64(e.file.Size)
+		lastUpdate = e.file.LastUpdate
+	}
+	if lastUpdate != nil {
+		info.modTime = *lastUpdate
+	}
+	return info
+}
+
+func (i *fileInfo) Name() string       { return i.name }
+func (i *fileInfo) Size() int64        { return i.size }
+func (i *fileInfo) ModTime() time.Time { return i.modTime }
+func (i *fileInfo) IsDir() bool        { return i.dir }
+func (i *fileInfo) Sys() any           { return nil }
+
+func (i *fileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+
+func (i *fileInfo) Type() fs.FileMode          { return i.Mode().Type() }
+func (i *fileInfo) Info() (fs.FileInfo, error) { return i, nil }
+func (i *fileInfo) String() string             { return fs.FormatFileInfo(i) }
+
+// fsDir is an open directory. Its entries are listed on the first ReadDir.
+type fsDir struct {
+	fsys    *FS
+	path    string
+	folder  *Folder
+	info    *fileInfo
+	entries []fs.DirEntry
+	listed  bool
+	offset  int
+}
+
+func (d *fsDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+
+func (d *fsDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.path, Err: errors.New("is a directory")}
+}
+
+func (d *fsDir) Close() error { return nil }
+
+// ReadDir returns the next n entries, or all remaining ones if n <= 0, as fs.ReadDirFile requires.
+func (d *fsDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !d.listed {
+		entries, err := d.fsys.readDir("readdir", d.path, d.folder)
+		if err != nil {
+			return nil, err
+		}
+		d.entries, d.listed = entries, true
+	}
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(rest) {
+		n = len(rest)
+	}
+	d.offset += n
+	return rest[:n], nil
+}
+
+// fsFile is an open file. The download starts on the first Read and continues from the current
+// offset after a Seek, using a range request, so http.FileServerFS can serve ranges.
+type fsFile struct {
+	fsys   *FS
+	path   string
+	info   *fileInfo
+	url    string
+	body   io.ReadCloser
+	offset int64 // Offset of the next byte Read returns
+	closed bool
+}
+
+func (f *fsFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+func (f *fsFile) Read(p []byte) (int, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "read", Path: f.path, Err: fs.ErrClosed}
+	}
+	if f.offset >= f.info.size {
+		return 0, io.EOF
+	}
+	if f.body == nil {
+		if err := f.download(); err != nil {
+			return 0, &fs.PathError{Op: "read", Path: f.path, Err: err}
+		}
+	}
+	n, err := f.body.Read(p)
+	f.offset += int64(n)
+	if err == io.EOF && f.offset < f.info.size {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+// download starts streaming the file from the current offset.
+func (f *fsFile) download() error {
+	req, err := http.NewRequestWithContext(f.fsys.ctx, http.MethodGet, f.url, nil)
+	if err != nil {
+		return err
+	}
+	if f.offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", f.offset))
+	}
+	// The client's timeout covers the whole response, which would cut long downloads short
+	httpClient := *f.fsys.client.httpClient
+	httpClient.Timeout = 0
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && f.offset > 0:
+	case resp.StatusCode == http.StatusOK && f.offset > 0:
+		// The server ignored the range; skip to the offset
+		if _, err := io.CopyN(io.Discard, resp.Body, f.offset); err != nil {
+			resp.Body.Close()
+			return err
+		}
+	case resp.StatusCode == http.StatusOK:
+	default:
+		resp.Body.Close()
+		return fmt.Errorf("download failed: %s", resp.Status)
+	}
+	f.body = resp.Body
+	return nil
+}
+
+// Seek sets the offset of the next Read. A started download is dropped when the offset changes.
+func (f *fsFile) Seek(offset int64, whence int) (int64, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "seek", Path: f.path, Err: fs.ErrClosed}
+	}
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.info.size
+	case io.SeekStart:
+	default:
+		return 0, &fs.PathError{Op: "seek", Path: f.path, Err: fs.ErrInvalid}
+	}
+	if offset < 0 {
+		return 0, &fs.PathError{Op: "seek", Path: f.path, Err: fs.ErrInvalid}
+	}
+	if offset != f.offset && f.body != nil {
+		f.body.Close()
+		f.body = nil
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *fsFile) Close() error {
+	if f.closed {
+		return &fs.PathError{Op: "close", Path: f.path, Err: fs.ErrClosed}
+	}
+	f.closed = true
+	if f.body != nil {
+		return f.body.Close()
+	}
+	return nil
+}
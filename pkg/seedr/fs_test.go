@@ -0,0 +1,185 @@
+package seedr
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+var fsTestContents = map[string]string{
+	"10": "hello",
+	"11": "hello world",
+	"12": "",
+}
+
+// newFSTestAPI returns a fake API holding:
+//
+//	a.txt
+//	empty.txt
+//	Movies/b.mkv
+//	Music/
+//
+// Downloads are served at /dl/<id>, honouring Range requests unless ignoreRange is set. The Range
+// header of every download is recorded in ranges.
+func newFSTestAPI(t *testing.T, ignoreRange bool) (api *fakeAPI, ranges func() []string) {
+	api = newFakeAPI(t)
+	api.Handle("list_contents", func(form url.Values) (int, any) {
+		switch form.Get("content_id") {
+		case "0":
+			return http.StatusOK, map[string]any{
+				"result": true,
+				"folders": []any{
+					map[string]any{"id": 1, "name": "Movies", "size": 11, "last_update": "2024-01-02 03:04:05"},
+					map[string]any{"id": 2, "name": "Music", "last_update": "2024-02-03 04:05:06"},
+				},
+				"files": []any{
+					map[string]any{"folder_file_id": 10, "name": "a.txt", "size": 5, "last_update": "2024-03-04 05:06:07"},
+					map[string]any{"folder_file_id": 12, "name": "empty.txt", "size": 0},
+				},
+			}
+		case "1":
+			return http.StatusOK, map[string]any{
+				"result": true,
+				"files":  []any{map[string]any{"folder_file_id": 11, "name": "b.mkv", "size": 11}},
+			}
+		case "2":
+			return http.StatusOK, map[string]any{"result": true}
+		}
+		return http.StatusNotFound, map[string]any{"result": false, "error": "no such folder"}
+	})
+	api.Handle("fetch_file", func(form url.Values) (int, any) {
+		id := form.Get("folder_file_id")
+		return http.StatusOK, map[string]any{"result": true, "url": api.Server.URL + "/dl/" + id}
+	})
+
+	var mu sync.Mutex
+	var seen []string
+	api.Mux.HandleFunc("/dl/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.Header.Get("Range"))
+		mu.Unlock()
+		content, ok := fsTestContents[strings.TrimPrefix(r.URL.Path, "/dl/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if ignoreRange {
+			r.Header.Del("Range")
+		}
+		http.ServeContent(w, r, "", time.Time{}, strings.NewReader(content))
+	})
+	return api, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), seen...)
+	}
+}
+
+func TestFS(t *testing.T) {
+	api, _ := newFSTestAPI(t, false)
+	if err := fstest.TestFS(NewFS(api.Client()), "a.txt", "empty.txt", "Movies/b.mkv", "Music"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFSStat(t *testing.T) {
+	api, _ := newFSTestAPI(t, false)
+	fsys := NewFS(api.Client())
+
+	info, err := fsys.Stat("a.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	if info.Size() != 5 || !info.ModTime().Equal(want) || info.IsDir() || info.Mode() != 0444 {
+		t.Errorf("Stat(a.txt) = %v, want a 5 byte read-only file modified at %v", fs.FormatFileInfo(info), want)
+	}
+	info, err = fsys.Stat("Movies")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.IsDir() || info.Mode() != fs.ModeDir|0555 {
+		t.Errorf("Stat(Movies) = %v, want a read-only directory", fs.FormatFileInfo(info))
+	}
+	for _, call := range api.Calls() {
+		if call.Func == "fetch_file" {
+			t.Errorf("Stat fetched a download URL")
+		}
+	}
+}
+
+func TestFSErrors(t *testing.T) {
+	api, _ := newFSTestAPI(t, false)
+	fsys := NewFS(api.Client())
+
+	for _, name := range []string{"missing", "Movies/missing.mkv", "a.txt/child"} {
+		if _, err := fsys.Open(name); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Open(%q) error = %v, want fs.ErrNotExist", name, err)
+		}
+	}
+	for _, name := range []string{"/a.txt", "Movies/", "../a.txt"} {
+		if _, err := fsys.Open(name); !errors.Is(err, fs.ErrInvalid) {
+			t.Errorf("Open(%q) error = %v, want fs.ErrInvalid", name, err)
+		}
+	}
+	if _, err := fsys.ReadDir("a.txt"); err == nil {
+		t.Errorf("ReadDir on a file succeeded")
+	}
+}
+
+func TestFSSeek(t *testing.T) {
+	for _, ignoreRange := range []bool{false, true} {
+		api, ranges := newFSTestAPI(t, ignoreRange)
+		f, err := NewFS(api.Client()).Open("Movies/b.mkv")
+		if err != nil {
+			t.Fatal(err)
+		}
+		seeker := f.(io.ReadSeeker)
+
+		head := make([]byte, 5)
+		if _, err := io.ReadFull(seeker, head); err != nil || string(head) != "hello" {
+			t.Fatalf("ignoreRange=%v: first read = %q, %v; want \"hello\"", ignoreRange, head, err)
+		}
+		if pos, err := seeker.Seek(-5, io.SeekEnd); err != nil || pos != 6 {
+			t.Fatalf("ignoreRange=%v: Seek = %d, %v; want 6", ignoreRange, pos, err)
+		}
+		rest, err := io.ReadAll(seeker)
+		if err != nil || string(rest) != "world" {
+			t.Errorf("ignoreRange=%v: read after seek = %q, %v; want \"world\"", ignoreRange, rest, err)
+		}
+		if got := ranges(); len(got) != 2 || got[0] != "" || got[1] != "bytes=6-" {
+			t.Errorf("ignoreRange=%v: Range headers = %q, want [\"\" \"bytes=6-\"]", ignoreRange, got)
+		}
+
+		if _, err := seeker.Seek(-1, io.SeekStart); err == nil {
+			t.Errorf("ignoreRange=%v: seeking before the start succeeded", ignoreRange)
+		}
+		if err := f.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := seeker.Read(head); !errors.Is(err, fs.ErrClosed) {
+			t.Errorf("ignoreRange=%v: Read after Close error = %v, want fs.ErrClosed", ignoreRange, err)
+		}
+	}
+}
+
+func TestFSFileServer(t *testing.T) {
+	api, _ := newFSTestAPI(t, false)
+	handler := http.FileServerFS(NewFS(api.Client()))
+
+	req, _ := http.NewRequest(http.MethodGet, "/Movies/b.mkv", nil)
+	req.Header.Set("Range", "bytes=6-")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusPartialContent || w.Body.String() != "world" {
+		t.Errorf("range request = %d %q, want 206 \"world\"", w.Code, w.Body.String())
+	}
+}
@@ -1,6 +1,8 @@
 package seedr
 
 import (
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -46,12 +48,31 @@ type File struct {
 	Thumb       *string    `json:"thumb,omitempty"`
 }
 
+// Progress returns the playback position saved for a video by Seedr's players, or 0 if it has
+// none. The API reports it in seconds, but "h:mm:ss" values are accepted too. Positions are
+// read-only: the documented API has no call to save them.
+func (f File) Progress() time.Duration {
+	if f.VideoProgress == nil {
+		return 0
+	}
+	var seconds float64
+	for _, part := range strings.Split(strings.TrimSpace(*f.VideoProgress), ":") {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil || n < 0 {
+			return 0
+		}
+		seconds = seconds*60 + n
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
 // Folder represents a folder, which can contain files, torrents, and other folders.
 type Folder struct {
 	ID         int        `json:"id"`
 	Name       string     `json:"name"`
 	Fullname   string     `json:"fullname"`
 	Size       int        `json:"size"`
+	Hash       string     `json:"hash,omitempty"` // Infohash of the torrent the folder came from, when the API reports it
 	LastUpdate *time.Time `json:"last_update,omitempty"`
 	IsShared   bool       `json:"is_shared"`
 	PlayAudio  bool       `json:"play_audio"`
@@ -73,6 +94,15 @@ type AccountSettings struct {
 	EmailNewsletter   bool   `json:"email_newsletter"`
 }
 
+// SettingsUpdate lists account settings to change. Nil fields are left unchanged.
+type SettingsUpdate struct {
+	AllowRemoteAccess  *bool
+	SiteLanguage       *string
+	SubtitlesLanguage  *string
+	EmailAnnouncements *bool
+	EmailNewsletter    *bool
+}
+
 // AccountInfo represents the nested 'account' object in the user settings response.
 type AccountInfo struct {
 	Username      string        `json:"username"`
@@ -84,7 +114,7 @@ type AccountInfo struct {
 	SpaceMax      int           `json:"space_max"`
 	BandwidthUsed int           `json:"bandwidth_used"`
 	Email         string        `json:"email"`
-	Wishlist      []interface{} `json:"wishlist"` // Python used list
+	Wishlist      []WishlistItem `json:"wishlist"`
 	Invites       int           `json:"invites"`
 	InvitesAccepted int           `json:"invites_accepted"`
 	MaxInvites    int           `json:"max_invites"`
@@ -116,6 +146,13 @@ type Device struct {
 	TK         string `json:"tk"`
 }
 
+// Masked returns a copy of the device with its secrets masked like Token.String does.
+func (d Device) Masked() Device {
+	d.TK = MaskSecret(d.TK)
+	d.DeviceCode = MaskSecret(d.DeviceCode)
+	return d
+}
+
 // DeviceCode represents the codes used in the device authentication flow.
 type DeviceCode struct {
 	ExpiresIn     int    `json:"expires_in"`
@@ -125,6 +162,16 @@ type DeviceCode struct {
 	VerificationURL string `json:"verification_url"`
 }
 
+// WishlistItem represents a torrent queued on the wishlist because there was not enough space to add it.
+type WishlistItem struct {
+	ID     int        `json:"id"`
+	Title  string     `json:"title"`
+	Size   int        `json:"size"`
+	Hash   string     `json:"hash"`
+	Magnet string     `json:"magnet"`
+	Added  *time.Time `json:"added,omitempty"`
+}
+
 // ScannedTorrent represents a torrent found by the scan_page method.
 type ScannedTorrent struct {
 	ID        int        `json:"id"`
@@ -187,6 +234,20 @@ type ScanPageResult struct {
 	Torrents []ScannedTorrent `json:"torrents"`
 }
 
+// SearchFilesResult represents the matches returned by a search_files request.
+// Matches keep their IDs and parent folder IDs so their location can be resolved.
+type SearchFilesResult struct {
+	Result  bool     `json:"result"`
+	Folders []Folder `json:"folders"`
+	Files   []File   `json:"files"`
+}
+
+// ItemRef identifies a file, folder or torrent in batch operations such as move and delete.
+type ItemRef struct {
+	Type string `json:"type"` // "file", "folder" or "torrent"
+	ID   string `json:"id"`
+}
+
 // APIResult represents a generic API result for operations that return a simple success/failure.
 type APIResult struct {
 	Result bool `json:"result"`
@@ -313,6 +374,9 @@ func NewFolderFromMap(data map[string]interface{}) Folder {
 	if size, ok := data["size"].(float64); ok {
 		f.Size = int(size)
 	}
+	if hash, ok := data["hash"].(string); ok {
+		f.Hash = hash
+	}
 	if lastUpdate := ParseDateTime(data["last_update"]); lastUpdate != nil {
 		f.LastUpdate = lastUpdate
 	} else if timestamp := ParseDateTime(data["timestamp"]); timestamp != nil {
@@ -411,7 +475,11 @@ func NewAccountInfoFromMap(data map[string]interface{}) AccountInfo {
 		ai.Email = v
 	}
 	if v, ok := data["wishlist"].([]interface{}); ok {
-		ai.Wishlist = v
+		for _, item := range v {
+			if itemMap, isMap := item.(map[string]interface{}); isMap {
+				ai.Wishlist = append(ai.Wishlist, NewWishlistItemFromMap(itemMap))
+			}
+		}
 	}
 	if v, ok := data["invites"].(float64); ok {
 		ai.Invites = int(v)
@@ -502,6 +570,44 @@ func NewDeviceCodeFromMap(data map[string]interface{}) DeviceCode {
 	return dc
 }
 
+// NewWishlistItemFromMap builds a WishlistItem. The API has used several key names for the
+// same fields over time, so the known alternatives are all accepted.
+func NewWishlistItemFromMap(data map[string]interface{}) WishlistItem {
+	wi := WishlistItem{}
+	switch v := data["id"].(type) {
+	case float64:
+		wi.ID = int(v)
+	case string:
+		if id, err := strconv.Atoi(v); err == nil {
+			wi.ID = id
+		}
+	}
+	for _, key := range []string{"title", "name"} {
+		if v, ok := data[key].(string); ok && wi.Title == "" {
+			wi.Title = v
+		}
+	}
+	if v, ok := data["size"].(float64); ok {
+		wi.Size = int(v)
+	}
+	for _, key := range []string{"hash", "torrent_hash"} {
+		if v, ok := data[key].(string); ok && wi.Hash == "" {
+			wi.Hash = v
+		}
+	}
+	for _, key := range []string{"magnet", "torrent_magnet"} {
+		if v, ok := data[key].(string); ok && wi.Magnet == "" {
+			wi.Magnet = v
+		}
+	}
+	for _, key := range []string{"added", "created", "last_update"} {
+		if wi.Added == nil {
+			wi.Added = ParseDateTime(data[key])
+		}
+	}
+	return wi
+}
+
 func NewScannedTorrentFromMap(data map[string]interface{}) ScannedTorrent {
 	st := ScannedTorrent{}
 	if v, ok := data["id"].(float64); ok {
@@ -649,6 +755,28 @@ func NewScanPageResultFromMap(data map[string]interface{}) ScanPageResult {
 	return spr
 }
 
+func NewSearchFilesResultFromMap(data map[string]interface{}) SearchFilesResult {
+	sfr := SearchFilesResult{}
+	if v, ok := data["result"].(bool); ok {
+		sfr.Result = v
+	}
+	if v, ok := data["folders"].([]interface{}); ok {
+		for _, item := range v {
+			if folderMap, isMap := item.(map[string]interface{}); isMap {
+				sfr.Folders = append(sfr.Folders, NewFolderFromMap(folderMap))
+			}
+		}
+	}
+	if v, ok := data["files"].([]interface{}); ok {
+		for _, item := range v {
+			if fileMap, isMap := item.(map[string]interface{}); isMap {
+				sfr.Files = append(sfr.Files, NewFileFromMap(fileMap))
+			}
+		}
+	}
+	return sfr
+}
+
 func NewAPIResultFromMap(data map[string]interface{}) APIResult {
 	ar := APIResult{}
 	if v, ok := data["result"].(bool); ok {
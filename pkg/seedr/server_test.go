@@ -0,0 +1,78 @@
+package seedr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+// apiCall is a request received by a fakeAPI.
+type apiCall struct {
+	Func string
+	Form url.Values
+}
+
+// fakeAPI is a stand-in for the Seedr API. It answers each call to resource.php with the handler
+// registered for its func parameter and records the calls. Other paths go to Mux.
+type fakeAPI struct {
+	Server *httptest.Server
+	Mux    *http.ServeMux
+
+	mu       sync.Mutex
+	handlers map[string]func(form url.Values) (int, any)
+	calls    []apiCall
+}
+
+// newFakeAPI starts a fakeAPI that is shut down when the test ends.
+func newFakeAPI(t *testing.T) *fakeAPI {
+	t.Helper()
+	api := &fakeAPI{Mux: http.NewServeMux(), handlers: make(map[string]func(url.Values) (int, any))}
+	api.Mux.HandleFunc("/oauth_test/resource.php", api.serveResource)
+	api.Server = httptest.NewServer(api.Mux)
+	t.Cleanup(api.Server.Close)
+	return api
+}
+
+// Handle answers calls of the API function fn with the status code and JSON body returned by h.
+func (api *fakeAPI) Handle(fn string, h func(form url.Values) (int, any)) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	api.handlers[fn] = h
+}
+
+// Calls returns the calls received so far.
+func (api *fakeAPI) Calls() []apiCall {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return append([]apiCall(nil), api.calls...)
+}
+
+// Client returns a client sending its requests to the fake API.
+func (api *fakeAPI) Client() *Client {
+	return NewClient(NewToken("test-token", nil, nil), WithBaseURL(api.Server.URL))
+}
+
+func (api *fakeAPI) serveResource(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(1 << 20); err != nil && err != http.ErrNotMultipart {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	fn := r.URL.Query().Get("func")
+	form := r.PostForm
+
+	api.mu.Lock()
+	api.calls = append(api.calls, apiCall{Func: fn, Form: form})
+	h, ok := api.handlers[fn]
+	api.mu.Unlock()
+	if !ok {
+		http.Error(w, "unexpected call to "+fn, http.StatusNotImplemented)
+		return
+	}
+	status, body := h(form)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -62,29 +62,30 @@ func (t *Token) ToBase64() (string, error) {
 	return base64.StdEncoding.EncodeToString([]byte(jsonStr)), nil
 }
 
+// MaskSecret hides all but the first few characters of a secret so it can be shown safely.
+func MaskSecret(value string) string {
+	if value == "" {
+		return "None"
+	}
+	if len(value) > 5 {
+		return value[:5] + "****"
+	}
+	return "****"
+}
+
 // String provides a safe, masked representation of the Token that avoids leaking secrets.
 func (t *Token) String() string {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 
-	mask := func(value string) string {
-		if value == "" {
-			return "None"
-		}
-		if len(value) > 5 {
-			return value[:5] + "****"
-		}
-		return "****"
-	}
-
 	parts := []string{
-		fmt.Sprintf("access_token=%s", mask(t.AccessToken)),
+		fmt.Sprintf("access_token=%s", MaskSecret(t.AccessToken)),
 	}
 	if t.RefreshToken != nil {
-		parts = append(parts, fmt.Sprintf("refresh_token=%s", mask(*t.RefreshToken)))
+		parts = append(parts, fmt.Sprintf("refresh_token=%s", MaskSecret(*t.RefreshToken)))
 	}
 	if t.DeviceCode != nil {
-		parts = append(parts, fmt.Sprintf("device_code=%s", mask(*t.DeviceCode)))
+		parts = append(parts, fmt.Sprintf("device_code=%s", MaskSecret(*t.DeviceCode)))
 	}
 	return fmt.Sprintf("Token(%s)", strings.Join(parts, ", "))
 }
@@ -0,0 +1,395 @@
+package seedr
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TorrentInfo describes a torrent parsed from a .torrent file or a magnet link.
+// Magnet links usually carry only the infohash, name and trackers; Files and TotalSize
+// are then empty unless the link has an exact length (xl).
+type TorrentInfo struct {
+	Name       string            `json:"name"`
+	InfoHashV1 string            `json:"infohash_v1,omitempty"` // Hex SHA-1 of the info dictionary
+	InfoHashV2 string            `json:"infohash_v2,omitempty"` // Hex SHA-256 of the info dictionary
+	Trackers   []string          `json:"trackers,omitempty"`
+	Files      []TorrentInfoFile `json:"files,omitempty"`
+	TotalSize  int64             `json:"total_size"`
+	PieceSize  int64             `json:"piece_size,omitempty"`
+	Private    bool              `json:"private,omitempty"`
+	Comment    string            `json:"comment,omitempty"`
+	CreatedBy  string            `json:"created_by,omitempty"`
+}
+
+// TorrentInfoFile is a single file listed in a torrent.
+type TorrentInfoFile struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// MatchesHash reports whether hash (as reported in Torrent.Hash) identifies this torrent.
+// Hybrid and v2 torrents are also matched on the SHA-256 hash truncated to 20 bytes,
+// which is how v2 infohashes appear in v1 contexts.
+func (t *TorrentInfo) MatchesHash(hash string) bool {
+	hash = strings.ToLower(strings.TrimSpace(hash))
+	if hash == "" {
+		return false
+	}
+	if t.InfoHashV1 != "" && hash == t.InfoHashV1 {
+		return true
+	}
+	if t.InfoHashV2 != "" && (hash == t.InfoHashV2 || hash == t.InfoHashV2[:40]) {
+		return true
+	}
+	return false
+}
+
+// Magnet returns a magnet link for the torrent, with its name and trackers.
+func (t *TorrentInfo) Magnet() string {
+	var parts []string
+	if t.InfoHashV1 != "" {
+		parts = append(parts, "xt=urn:btih:"+t.InfoHashV1)
+	}
+	if t.InfoHashV2 != "" {
+		parts = append(parts, "xt=urn:btmh:1220"+t.InfoHashV2)
+	}
+	if t.Name != "" {
+		parts = append(parts, "dn="+url.QueryEscape(t.Name))
+	}
+	if t.TotalSize > 0 {
+		parts = append(parts, "xl="+strconv.FormatInt(t.TotalSize, 10))
+	}
+	for _, tr := range t.Trackers {
+		parts = append(parts, "tr="+url.QueryEscape(tr))
+	}
+	return "magnet:?" + strings.Join(parts, "&")
+}
+
+// ParseTorrentFile parses the contents of a .torrent file.
+// Both v1 (files/length) and v2 (file tree) layouts are supported, as are hybrid torrents.
+func ParseTorrentFile(data []byte) (*TorrentInfo, error) {
+	d := &bdecoder{data: data}
+	root, err := d.decode()
+	if err != nil {
+		return nil, fmt.Errorf("invalid torrent file: %w", err)
+	}
+	meta, ok := root.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid torrent file: top level is not a dictionary")
+	}
+	info, ok := meta["info"].(map[string]interface{})
+	if !ok || d.infoEnd == 0 {
+		return nil, fmt.Errorf("invalid torrent file: missing info dictionary")
+	}
+	rawInfo := data[d.infoStart:d.infoEnd]
+
+	t := &TorrentInfo{
+		Name:      bstring(info["name.utf-8"]),
+		PieceSize: bint(info["piece length"]),
+		Private:   bint(info["private"]) == 1,
+		Comment:   bstring(meta["comment"]),
+		CreatedBy: bstring(meta["created by"]),
+	}
+	if t.Name == "" {
+		t.Name = bstring(info["name"])
+	}
+
+	metaVersion := bint(info["meta version"])
+	if _, hasV1Layout := info["pieces"]; hasV1Layout || metaVersion < 2 {
+		sum := sha1.Sum(rawInfo)
+		t.InfoHashV1 = hex.EncodeToString(sum[:])
+	}
+	if metaVersion == 2 {
+		sum := sha256.Sum256(rawInfo)
+		t.InfoHashV2 = hex.EncodeToString(sum[:])
+	}
+
+	t.Trackers = collectTrackers(meta)
+
+	switch {
+	case info["files"] != nil:
+		for _, f := range blist(info["files"]) {
+			fm, _ := f.(map[string]interface{})
+			pathList := blist(fm["path.utf-8"])
+			if pathList == nil {
+				pathList = blist(fm["path"])
+			}
+			var segments []string
+			for _, seg := range pathList {
+				segments = append(segments, bstring(seg))
+			}
+			if attr := bstring(fm["attr"]); strings.Contains(attr, "p") {
+				continue // Padding files are not real content
+			}
+			t.Files = append(t.Files, TorrentInfoFile{Path: path.Join(segments...), Size: bint(fm["length"])})
+		}
+	case info["length"] != nil:
+		t.Files = []TorrentInfoFile{{Path: t.Name, Size: bint(info["length"])}}
+	case info["file tree"] != nil:
+		tree, _ := info["file tree"].(map[string]interface{})
+		walkFileTree(tree, "", &t.Files)
+	}
+	for _, f := range t.Files {
+		if f.Size < 0 {
+			return nil, fmt.Errorf("invalid torrent file: negative length for '%s'", f.Path)
+		}
+		if f.Size > math.MaxInt64-t.TotalSize {
+			return nil, fmt.Errorf("invalid torrent file: total size overflows")
+		}
+		t.TotalSize += f.Size
+	}
+	return t, nil
+}
+
+// ParseMagnet parses a magnet URI. It understands BitTorrent v1 (btih, hex or base32)
+// and v2 (btmh) infohashes, the display name (dn), trackers (tr) and exact length (xl).
+func ParseMagnet(uri string) (*TorrentInfo, error) {
+	if !strings.HasPrefix(strings.ToLower(uri), "magnet:?") {
+		return nil, fmt.Errorf("invalid magnet link: missing magnet:? prefix")
+	}
+	values, err := url.ParseQuery(uri[len("magnet:?"):])
+	if err != nil {
+		return nil, fmt.Errorf("invalid magnet link: %w", err)
+	}
+
+	t := &TorrentInfo{Name: values.Get("dn"), Trackers: values["tr"]}
+	for _, xt := range values["xt"] {
+		lower := strings.ToLower(xt)
+		switch {
+		case strings.HasPrefix(lower, "urn:btih:"):
+			hash, err := decodeBTIH(xt[len("urn:btih:"):])
+			if err != nil {
+				return nil, err
+			}
+			t.InfoHashV1 = hash
+		case strings.HasPrefix(lower, "urn:btmh:"):
+			// Multihash: 0x12 (sha2-256) and 0x20 (32 bytes) precede the digest
+			mh := lower[len("urn:btmh:"):]
+			if !strings.HasPrefix(mh, "1220") || len(mh) != 4+64 {
+				return nil, fmt.Errorf("invalid magnet link: unsupported btmh hash '%s'", mh)
+			}
+			if _, err := hex.DecodeString(mh[4:]); err != nil {
+				return nil, fmt.Errorf("invalid magnet link: %w", err)
+			}
+			t.InfoHashV2 = mh[4:]
+		}
+	}
+	if t.InfoHashV1 == "" && t.InfoHashV2 == "" {
+		return nil, fmt.Errorf("invalid magnet link: no BitTorrent infohash (xt)")
+	}
+	if xl := values.Get("xl"); xl != "" {
+		if size, err := strconv.ParseInt(xl, 10, 64); err == nil && size >= 0 {
+			t.TotalSize = size
+		}
+	}
+	return t, nil
+}
+
+// TorrentInfoFromHash builds a TorrentInfo from a bare infohash, such as Torrent.Hash, so that a
+// magnet link can be rebuilt with Magnet. A 64-character hex hash is taken as a v2 hash; 40-character
+// hex and 32-character base32 hashes as v1 hashes.
+func TorrentInfoFromHash(hash, name string) (*TorrentInfo, error) {
+	hash = strings.TrimSpace(hash)
+	t := &TorrentInfo{Name: name}
+	if len(hash) == 64 {
+		if _, err := hex.DecodeString(hash); err != nil {
+			return nil, fmt.Errorf("invalid infohash '%s': %w", hash, err)
+		}
+		t.InfoHashV2 = strings.ToLower(hash)
+		return t, nil
+	}
+	v1, err := decodeBTIH(hash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid infohash '%s'", hash)
+	}
+	t.InfoHashV1 = v1
+	return t, nil
+}
+
+// decodeBTIH normalizes a v1 infohash, given as 40 hex or 32 base32 characters, to lowercase hex.
+func decodeBTIH(s string) (string, error) {
+	switch len(s) {
+	case 40:
+		if _, err := hex.DecodeString(s); err != nil {
+			return "", fmt.Errorf("invalid magnet link: %w", err)
+		}
+		return strings.ToLower(s), nil
+	case 32:
+		raw, err := base32.StdEncoding.DecodeString(strings.ToUpper(s))
+		if err != nil {
+			return "", fmt.Errorf("invalid magnet link: %w", err)
+		}
+		return hex.EncodeToString(raw), nil
+	default:
+		return "", fmt.Errorf("invalid magnet link: infohash '%s' has an unexpected length", s)
+	}
+}
+
+// collectTrackers merges announce and announce-list, dropping duplicates.
+func collectTrackers(meta map[string]interface{}) []string {
+	var trackers []string
+	seen := make(map[string]bool)
+	add := func(tr string) {
+		if tr != "" && !seen[tr] {
+			seen[tr] = true
+			trackers = append(trackers, tr)
+		}
+	}
+	add(bstring(meta["announce"]))
+	for _, tier := range blist(meta["announce-list"]) {
+		for _, tr := range blist(tier) {
+			add(bstring(tr))
+		}
+	}
+	return trackers
+}
+
+// walkFileTree flattens a v2 file tree. Files are dictionaries with an empty key holding their length.
+func walkFileTree(tree map[string]interface{}, prefix string, files *[]TorrentInfoFile) {
+	names := make([]string, 0, len(tree))
+	for name := range tree {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		node, _ := tree[name].(map[string]interface{})
+		if leaf, ok := node[""].(map[string]interface{}); ok {
+			*files = append(*files, TorrentInfoFile{Path: path.Join(prefix, name), Size: bint(leaf["length"])})
+			continue
+		}
+		walkFileTree(node, path.Join(prefix, name), files)
+	}
+}
+
+// bstring, bint and blist convert decoded bencode values, returning zero values on type mismatch.
+func bstring(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+func bint(v interface{}) int64 {
+	i, _ := v.(int64)
+	return i
+}
+
+func blist(v interface{}) []interface{} {
+	l, _ := v.([]interface{})
+	return l
+}
+
+// bdecoder decodes bencoded data into int64, string, []interface{} and map[string]interface{} values.
+// It records the byte range of the top-level "info" dictionary so its hash can be computed.
+type bdecoder struct {
+	data      []byte
+	pos       int
+	depth     int
+	infoStart int
+	infoEnd   int
+}
+
+// bencodeMaxDepth guards against stack exhaustion on malicious input.
+const bencodeMaxDepth = 64
+
+func (d *bdecoder) decode() (interface{}, error) {
+	if d.pos >= len(d.data) {
+		return nil, fmt.Errorf("unexpected end of data")
+	}
+	switch c := d.data[d.pos]; {
+	case c == 'i':
+		end := bytes.IndexByte(d.data[d.pos:], 'e')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated integer at offset %d", d.pos)
+		}
+		n, err := strconv.ParseInt(string(d.data[d.pos+1:d.pos+end]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer at offset %d: %w", d.pos, err)
+		}
+		d.pos += end + 1
+		return n, nil
+	case c >= '0' && c <= '9':
+		return d.decodeString()
+	case c == 'l':
+		if err := d.enter(); err != nil {
+			return nil, err
+		}
+		d.pos++
+		list := []interface{}{}
+		for d.pos < len(d.data) && d.data[d.pos] != 'e' {
+			v, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		if d.pos >= len(d.data) {
+			return nil, fmt.Errorf("unterminated list")
+		}
+		d.pos++
+		d.depth--
+		return list, nil
+	case c == 'd':
+		if err := d.enter(); err != nil {
+			return nil, err
+		}
+		d.pos++
+		dict := make(map[string]interface{})
+		for d.pos < len(d.data) && d.data[d.pos] != 'e' {
+			key, err := d.decodeString()
+			if err != nil {
+				return nil, fmt.Errorf("invalid dictionary key: %w", err)
+			}
+			start := d.pos
+			v, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			if d.depth == 1 && key == "info" {
+				d.infoStart, d.infoEnd = start, d.pos
+			}
+			dict[key] = v
+		}
+		if d.pos >= len(d.data) {
+			return nil, fmt.Errorf("unterminated dictionary")
+		}
+		d.pos++
+		d.depth--
+		return dict, nil
+	default:
+		return nil, fmt.Errorf("unexpected byte %q at offset %d", c, d.pos)
+	}
+}
+
+func (d *bdecoder) enter() error {
+	d.depth++
+	if d.depth > bencodeMaxDepth {
+		return fmt.Errorf("nesting deeper than %d levels", bencodeMaxDepth)
+	}
+	return nil
+}
+
+func (d *bdecoder) decodeString() (string, error) {
+	colon := bytes.IndexByte(d.data[d.pos:], ':')
+	if colon < 0 {
+		return "", fmt.Errorf("invalid string at offset %d", d.pos)
+	}
+	n, err := strconv.Atoi(string(d.data[d.pos : d.pos+colon]))
+	if err != nil || n < 0 {
+		return "", fmt.Errorf("invalid string length at offset %d", d.pos)
+	}
+	start := d.pos + colon + 1
+	if n > len(d.data)-start { // start+n could overflow
+		return "", fmt.Errorf("string at offset %d runs past the end of data", d.pos)
+	}
+	d.pos = start + n
+	return string(d.data[start : start+n]), nil
+}
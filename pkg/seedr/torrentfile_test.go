@@ -0,0 +1,287 @@
+package seedr
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// bencode encodes strings, ints, lists and dictionaries, with dictionary keys sorted as the format
+// requires, to build test torrents.
+func bencode(v any) string {
+	switch v := v.(type) {
+	case string:
+		return fmt.Sprintf("%d:%s", len(v), v)
+	case int:
+		return fmt.Sprintf("i%de", v)
+	case []any:
+		var b strings.Builder
+		b.WriteString("l")
+		for _, item := range v {
+			b.WriteString(bencode(item))
+		}
+		return b.String() + "e"
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var b strings.Builder
+		b.WriteString("d")
+		for _, k := range keys {
+			b.WriteString(bencode(k) + bencode(v[k]))
+		}
+		return b.String() + "e"
+	}
+	panic(fmt.Sprintf("cannot bencode %T", v))
+}
+
+func sha1Hex(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// v2File is a file in a v2 file tree.
+func v2File(length int) map[string]any {
+	return map[string]any{"": map[string]any{"length": length, "pieces root": strings.Repeat("r", 32)}}
+}
+
+func TestParseTorrentFile(t *testing.T) {
+	v1Single := map[string]any{"name": "movie.mkv", "length": 1000, "piece length": 256, "pieces": strings.Repeat("p", 20)}
+	v1Multi := map[string]any{
+		"name":         "Album",
+		"name.utf-8":   "Album ü",
+		"piece length": 16384,
+		"pieces":       strings.Repeat("p", 20),
+		"private":      1,
+		"files": []any{
+			map[string]any{"length": 100, "path": []any{"CD1", "01.flac"}},
+			map[string]any{"length": 28, "path": []any{".pad", "28"}, "attr": "p"},
+			map[string]any{"length": 200, "path": []any{"CD2", "02.flac"}, "path.utf-8": []any{"CD2", "02 ü.flac"}},
+		},
+	}
+	v2 := map[string]any{
+		"name":         "Show",
+		"meta version": 2,
+		"piece length": 16384,
+		"file tree": map[string]any{
+			"b.mkv": v2File(300),
+			"Extras": map[string]any{
+				"a.nfo": v2File(5),
+			},
+		},
+	}
+	hybrid := map[string]any{
+		"name":         "Hybrid",
+		"meta version": 2,
+		"piece length": 16384,
+		"pieces":       strings.Repeat("p", 20),
+		"files": []any{
+			map[string]any{"length": 10, "path": []any{"a.txt"}},
+			map[string]any{"length": 6, "path": []any{".pad", "6"}, "attr": "p"},
+			map[string]any{"length": 20, "path": []any{"b.txt"}},
+		},
+		"file tree": map[string]any{"a.txt": v2File(10), "b.txt": v2File(20)},
+	}
+
+	tests := []struct {
+		name string
+		meta map[string]any
+		want TorrentInfo
+	}{
+		{
+			name: "v1 single file",
+			meta: map[string]any{"announce": "udp://t1/announce", "comment": "hi", "created by": "test", "info": v1Single},
+			want: TorrentInfo{
+				Name: "movie.mkv", InfoHashV1: sha1Hex(bencode(v1Single)), Trackers: []string{"udp://t1/announce"},
+				Files: []TorrentInfoFile{{Path: "movie.mkv", Size: 1000}}, TotalSize: 1000, PieceSize: 256,
+				Comment: "hi", CreatedBy: "test",
+			},
+		},
+		{
+			name: "v1 multiple files with padding",
+			meta: map[string]any{
+				"announce":      "udp://t1/announce",
+				"announce-list": []any{[]any{"udp://t1/announce", "udp://t2/announce"}, []any{"http://t3/announce"}},
+				"info":          v1Multi,
+			},
+			want: TorrentInfo{
+				Name: "Album ü", InfoHashV1: sha1Hex(bencode(v1Multi)),
+				Trackers:  []string{"udp://t1/announce", "udp://t2/announce", "http://t3/announce"},
+				Files:     []TorrentInfoFile{{Path: "CD1/01.flac", Size: 100}, {Path: "CD2/02 ü.flac", Size: 200}},
+				TotalSize: 300, PieceSize: 16384, Private: true,
+			},
+		},
+		{
+			name: "v2",
+			meta: map[string]any{"info": v2},
+			want: TorrentInfo{
+				Name: "Show", InfoHashV2: sha256Hex(bencode(v2)),
+				Files:     []TorrentInfoFile{{Path: "Extras/a.nfo", Size: 5}, {Path: "b.mkv", Size: 300}},
+				TotalSize: 305, PieceSize: 16384,
+			},
+		},
+		{
+			name: "hybrid",
+			meta: map[string]any{"info": hybrid},
+			want: TorrentInfo{
+				Name: "Hybrid", InfoHashV1: sha1Hex(bencode(hybrid)), InfoHashV2: sha256Hex(bencode(hybrid)),
+				Files:     []TorrentInfoFile{{Path: "a.txt", Size: 10}, {Path: "b.txt", Size: 20}},
+				TotalSize: 30, PieceSize: 16384,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTorrentFile([]byte(bencode(tt.meta)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTorrentFileHashesRawInfo(t *testing.T) {
+	// The infohash covers the info dictionary exactly as stored, even with keys out of order
+	info := "d6:lengthi5e4:name1:x6:pieces20:" + strings.Repeat("p", 20) + "12:piece lengthi1ee"
+	got, err := ParseTorrentFile([]byte("d4:info" + info + "e"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.InfoHashV1 != sha1Hex(info) {
+		t.Errorf("InfoHashV1 = %s, want the SHA-1 of the raw info dictionary %s", got.InfoHashV1, sha1Hex(info))
+	}
+}
+
+func TestParseTorrentFileMalformed(t *testing.T) {
+	deep := strings.Repeat("l", bencodeMaxDepth+1) + strings.Repeat("e", bencodeMaxDepth+1)
+	tests := []struct {
+		name, data string
+	}{
+		{"empty", ""},
+		{"not bencode", "hello"},
+		{"top level list", "le"},
+		{"no info", "d8:announce3:urle"},
+		{"info not a dictionary", "d4:info3:abce"},
+		{"truncated dictionary", "d4:infod4:name1:x"},
+		{"truncated list", "d4:infod5:filesl"},
+		{"truncated string", "d4:info5:ab"},
+		{"unterminated integer", "d4:infod6:lengthi12"},
+		{"invalid integer", "d4:infod6:lengthi1x2ee4:name1:xee"},
+		{"string length overflow", "9223372036854775807:"},
+		{"info string length overflow", "d4:info9223372036854775807:xe"},
+		{"string length past int64", "99999999999999999999:x"},
+		{"negative string length", "d4:info-1:xe"},
+		{"non-string key", "di1ei2ee"},
+		{"too deep", "d4:info" + deep + "e"},
+		{"negative length", bencode(map[string]any{"info": map[string]any{"name": "x", "length": -5, "pieces": ""}})},
+		{"negative file length", bencode(map[string]any{"info": map[string]any{"name": "x", "pieces": "", "files": []any{
+			map[string]any{"length": 10, "path": []any{"a"}},
+			map[string]any{"length": -10, "path": []any{"b"}},
+		}}})},
+		{"negative v2 length", bencode(map[string]any{"info": map[string]any{"name": "x", "meta version": 2, "file tree": map[string]any{"a": v2File(-1)}}})},
+		{"total size overflow", bencode(map[string]any{"info": map[string]any{"name": "x", "pieces": "", "files": []any{
+			map[string]any{"length": 1 << 62, "path": []any{"a"}},
+			map[string]any{"length": 1 << 62, "path": []any{"b"}},
+		}}})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTorrentFile([]byte(tt.data))
+			if err == nil {
+				t.Errorf("ParseTorrentFile(%q) = %+v, want an error", tt.data, got)
+			}
+		})
+	}
+}
+
+func TestParseMagnet(t *testing.T) {
+	const (
+		v1     = "c12fe1c06bba254a9dc9f519b335aa7c1367a88a"
+		v1B32  = "YEX6DQDLXISUVHOJ6UM3GNNKPQJWPKEK"
+		v2     = "d8dd32ac93357c368556af3ac1d95c9d76bd0dff6fa9833ecdac3d53134efabb"
+		v2Long = "urn:btmh:1220" + v2
+	)
+	tests := []struct {
+		name, uri string
+		want      TorrentInfo
+	}{
+		{"hex btih", "magnet:?xt=urn:btih:" + strings.ToUpper(v1), TorrentInfo{InfoHashV1: v1}},
+		{"base32 btih", "magnet:?xt=urn:btih:" + v1B32, TorrentInfo{InfoHashV1: v1}},
+		{"btmh", "magnet:?xt=" + v2Long, TorrentInfo{InfoHashV2: v2}},
+		{"hybrid", "magnet:?xt=urn:btih:" + v1 + "&xt=" + v2Long, TorrentInfo{InfoHashV1: v1, InfoHashV2: v2}},
+		{
+			"name, trackers and length",
+			"magnet:?xt=urn:btih:" + v1 + "&dn=Big+Buck%20Bunny&tr=udp%3A%2F%2Ft1%3A80&tr=http://t2/announce&xl=1234",
+			TorrentInfo{Name: "Big Buck Bunny", InfoHashV1: v1, Trackers: []string{"udp://t1:80", "http://t2/announce"}, TotalSize: 1234},
+		},
+		{"negative length ignored", "magnet:?xt=urn:btih:" + v1 + "&xl=-5", TorrentInfo{InfoHashV1: v1}},
+		{"other xt ignored", "magnet:?xt=urn:sha1:abc&xt=urn:btih:" + v1, TorrentInfo{InfoHashV1: v1}},
+		{"prefix case", "MAGNET:?xt=urn:btih:" + v1, TorrentInfo{InfoHashV1: v1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMagnet(tt.uri)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("got  %+v\nwant %+v", *got, tt.want)
+			}
+
+			// The rebuilt link parses to the same torrent
+			again, err := ParseMagnet(got.Magnet())
+			if err != nil || !reflect.DeepEqual(*again, *got) {
+				t.Errorf("Magnet() = %q parses to %+v, %v; want %+v", got.Magnet(), again, err, *got)
+			}
+		})
+	}
+}
+
+func TestParseMagnetMalformed(t *testing.T) {
+	for _, uri := range []string{
+		"",
+		"http://example.com/x.torrent",
+		"magnet:?dn=no+hash",
+		"magnet:?xt=urn:btih:abc",
+		"magnet:?xt=urn:btih:" + strings.Repeat("z", 40),
+		"magnet:?xt=urn:btih:" + strings.Repeat("1", 32),
+		"magnet:?xt=urn:btmh:1114" + strings.Repeat("a", 40),
+		"magnet:?xt=urn:btmh:1220" + strings.Repeat("g", 64),
+		"magnet:?xt=urn:btih:%zz",
+	} {
+		if got, err := ParseMagnet(uri); err == nil {
+			t.Errorf("ParseMagnet(%q) = %+v, want an error", uri, got)
+		}
+	}
+}
+
+func TestMatchesHash(t *testing.T) {
+	v2 := sha256Hex("x")
+	info := &TorrentInfo{InfoHashV1: sha1Hex("x"), InfoHashV2: v2}
+	for hash, want := range map[string]bool{
+		strings.ToUpper(sha1Hex("x")): true,
+		v2:                            true,
+		v2[:40]:                       true,
+		sha1Hex("y"):                  false,
+		"":                            false,
+	} {
+		if got := info.MatchesHash(hash); got != want {
+			t.Errorf("MatchesHash(%q) = %v, want %v", hash, got, want)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package seedr
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -114,8 +115,27 @@ func PrepareRenamePayload(renameTo string, fileID, folderID string) map[string]s
 
 // PrepareDeleteItemPayload prepares the data payload for deleting an item.
 func PrepareDeleteItemPayload(itemType, itemID string) map[string]string {
+	return PrepareDeleteItemsPayload([]ItemRef{{Type: itemType, ID: itemID}})
+}
+
+// PrepareDeleteItemsPayload prepares the data payload for deleting several items at once.
+func PrepareDeleteItemsPayload(items []ItemRef) map[string]string {
 	// The Python version uses a JSON string.
-	return map[string]string{"delete_arr": fmt.Sprintf(`[{"type":"%s","id":%s}]`, itemType, itemID)}
+	return map[string]string{"delete_arr": FormatItemArray(items)}
+}
+
+// FormatItemArray encodes items in the JSON array format used by the delete_arr and move_arr fields.
+func FormatItemArray(items []ItemRef) string {
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		parts = append(parts, fmt.Sprintf(`{"type":"%s","id":%s}`, item.Type, item.ID))
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
+
+// PrepareMovePayload prepares the data payload for moving items into another folder.
+func PrepareMovePayload(items []ItemRef, destFolderID string) map[string]string {
+	return map[string]string{"move_arr": FormatItemArray(items), "move_to": destFolderID}
 }
 
 // PrepareRemoveWishlistPayload prepares the data payload for removing a wishlist item.
@@ -123,6 +143,11 @@ func PrepareRemoveWishlistPayload(wishlistID string) map[string]string {
 	return map[string]string{"id": wishlistID}
 }
 
+// PrepareRemoveDevicePayload prepares the data payload for revoking a device's access.
+func PrepareRemoveDevicePayload(clientID string) map[string]string {
+	return map[string]string{"client_id": clientID}
+}
+
 // PrepareAddFolderPayload prepares the data payload for adding a folder.
 func PrepareAddFolderPayload(name string) map[string]string {
 	return map[string]string{"name": name}
@@ -133,6 +158,39 @@ func PrepareSearchFilesPayload(query string) map[string]string {
 	return map[string]string{"search_query": query}
 }
 
+// PrepareUpdateSettingPayload prepares the data payload for changing a single account setting.
+func PrepareUpdateSettingPayload(setting, value string) map[string]string {
+	return map[string]string{"setting": setting, setting: value}
+}
+
+// settingsUpdatePayloads returns one payload per field set in update, in a stable order.
+// Booleans are sent as "1" or "0".
+func settingsUpdatePayloads(update SettingsUpdate) []map[string]string {
+	boolValue := func(b bool) string {
+		if b {
+			return "1"
+		}
+		return "0"
+	}
+	var payloads []map[string]string
+	if update.AllowRemoteAccess != nil {
+		payloads = append(payloads, PrepareUpdateSettingPayload("allow_remote_access", boolValue(*update.AllowRemoteAccess)))
+	}
+	if update.SiteLanguage != nil {
+		payloads = append(payloads, PrepareUpdateSettingPayload("site_language", *update.SiteLanguage))
+	}
+	if update.SubtitlesLanguage != nil {
+		payloads = append(payloads, PrepareUpdateSettingPayload("subtitles_language", *update.SubtitlesLanguage))
+	}
+	if update.EmailAnnouncements != nil {
+		payloads = append(payloads, PrepareUpdateSettingPayload("email_announcements", boolValue(*update.EmailAnnouncements)))
+	}
+	if update.EmailNewsletter != nil {
+		payloads = append(payloads, PrepareUpdateSettingPayload("email_newsletter", boolValue(*update.EmailNewsletter)))
+	}
+	return payloads
+}
+
 // PrepareChangeNamePayload prepares the data payload for changing the account name.
 func PrepareChangeNamePayload(name, password string) map[string]string {
 	return map[string]string{"setting": "fullname", "password": password, "fullname": name}
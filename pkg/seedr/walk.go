@@ -0,0 +1,52 @@
+package seedr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+)
+
+// SkipDir can be returned by a WalkFunc for a folder to skip the folder's contents.
+var SkipDir = errors.New("skip this folder")
+
+// WalkFunc is called by Walk for every folder and file below the starting folder, with the item's
+// path relative to it, e.g. "Movies/x.mkv". Exactly one of folder and file is set. Returning
+// SkipDir for a folder skips its contents; any other error stops the walk and is returned by Walk.
+type WalkFunc func(p string, folder *Folder, file *File) error
+
+// Walk lists the folder with the given ID ("0" for the root) and everything below it, depth first,
+// calling fn for each folder before its contents and then for the folder's files.
+func (c *Client) Walk(ctx context.Context, folderID string, fn WalkFunc) error {
+	return c.walk(ctx, folderID, "", fn)
+}
+
+func (c *Client) walk(ctx context.Context, folderID, dir string, fn WalkFunc) error {
+	contents, err := c.ListContents(ctx, folderID)
+	if err != nil {
+		if dir == "" {
+			return err
+		}
+		return fmt.Errorf("listing %s: %w", dir, err)
+	}
+	for i := range contents.Folders {
+		folder := &contents.Folders[i]
+		p := path.Join(dir, folder.Name)
+		if err := fn(p, folder, nil); err != nil {
+			if errors.Is(err, SkipDir) {
+				continue
+			}
+			return err
+		}
+		if err := c.walk(ctx, fmt.Sprintf("%d", folder.ID), p, fn); err != nil {
+			return err
+		}
+	}
+	for i := range contents.Files {
+		file := &contents.Files[i]
+		if err := fn(path.Join(dir, file.Name), nil, file); err != nil && !errors.Is(err, SkipDir) {
+			return err
+		}
+	}
+	return nil
+}
@@ -7,8 +7,7 @@ import (
 	"io"
 	"net/http"
 	"os"
-	"os/exec"
-	"time"
+	"path/filepath"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -20,7 +19,7 @@ import (
 // COMMANDS
 func fetchContents(client *seedr.Client, folderID string) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), internal.Conf.RequestTimeout)
 		defer cancel()
 
 		if client == nil {
@@ -56,16 +55,21 @@ func fetchContents(client *seedr.Client, folderID string) tea.Cmd {
 
 		// Add files
 		for _, f := range contents.Files {
+			desc := fmt.Sprintf("File | Size: %.2fGB | Last Update: %s", float64(f.Size)/(1024*1024*1024), func() string {
+				if f.LastUpdate != nil {
+					return f.LastUpdate.Format("2006-01-02 15:04:05")
+				}
+				return "N/A"
+			}())
+			if f.Progress() > 0 {
+				desc += " | Watched to " + internal.FormatPosition(f.Progress())
+			}
 			allItems = append(allItems, item{
 				id:       fmt.Sprintf("%d", f.FolderFileID), // Corrected: Use f.FolderFileID for file IDs
 				itemType: TypeFile,
 				title:    f.Name,
-				desc:     fmt.Sprintf("File | Size: %.2fGB | Last Update: %s", float64(f.Size)/(1024*1024*1024), func() string {
-					if f.LastUpdate != nil {
-						return f.LastUpdate.Format("2006-01-02 15:04:05")
-					}
-					return "N/A"
-				}()),
+				desc:     desc,
+				start:    f.Progress(),
 			})
 		}
 		
@@ -99,7 +103,7 @@ func cmdDownloadFile(client *seedr.Client, fileID string, fileName string) tea.C
 		msgChan := make(chan tea.Msg)
 
 		go func() {
-			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			ctx, cancel := context.WithTimeout(context.Background(), internal.Conf.DownloadTimeout)
 			defer cancel()
 
 			fileResult, err := client.FetchFile(ctx, fileID)
@@ -120,7 +124,7 @@ func cmdDownloadFile(client *seedr.Client, fileID string, fileName string) tea.C
 				return
 			}
 
-			outFile, err := os.Create(fileName)
+			outFile, err := os.Create(filepath.Join(internal.Conf.DownloadDir, fileName))
 			if err != nil {
 				msgChan <- downloadErrorMsg{err: fmt.Errorf("failed to create local file %s: %w", fileName, err)}
 				return
@@ -162,49 +166,182 @@ func cmdDownloadFile(client *seedr.Client, fileID string, fileName string) tea.C
 
 func cmdCopyURL(client *seedr.Client, fileID string) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), internal.Conf.RequestTimeout)
 		defer cancel()
 
 		fileResult, err := client.FetchFile(ctx, fileID)
 		if err != nil {
 			return clipboardErrorMsg{err: fmt.Errorf("failed to get download URL for clipboard: %w", err)}
 		}
+		if err := internal.CopyToClipboard(fileResult.URL); err != nil {
+			return clipboardErrorMsg{err: err}
+		}
+		return clipboardCompleteMsg("URL copied to clipboard!")
+	}
+}
 
-		// Attempt to copy to clipboard using wl-copy (Wayland) or xclip (X11)
-		copyCmd := exec.Command("wl-copy", fileResult.URL)
-		if err := copyCmd.Run(); err != nil {
-			copyCmd = exec.Command("xclip", "-selection", "clipboard")
-			stdin, _ := copyCmd.StdinPipe()
-			go func() {
-				defer stdin.Close()
-				io.WriteString(stdin, fileResult.URL)
-			}()
-			if err := copyCmd.Run(); err != nil {
-				return clipboardErrorMsg{err: fmt.Errorf("failed to copy URL to clipboard: %w", err)}
+// cmdOpenMPV opens a file with the handler configured for its type, which is the player for videos.
+// When the player is mpv, the file replaces mpv's playlist instead of opening a new window.
+func cmdOpenMPV(player *internal.MPV, client *seedr.Client, file item) tea.Cmd {
+	handler, err := internal.HandlerFor(file.title)
+	if err != nil {
+		return func() tea.Msg { return openMPVErrorMsg{err: err} }
+	}
+	if handler.Name == internal.PlayerHandler && internal.IsMPV(internal.Conf.Player) {
+		return cmdQueueMPV(player, client, []item{file})
+	}
+	return cmdOpenWith(client, file, handler)
+}
+
+// cmdQueueMPV replaces mpv's playlist with files, connecting to mpv or starting it if player is nil.
+// The files' download URLs are fetched only when mpv gets to them.
+func cmdQueueMPV(player *internal.MPV, client *seedr.Client, files []item) tea.Cmd {
+	return func() tea.Msg {
+		if player == nil {
+			var err error
+			if player, err = internal.StartMPV(playerSource{client}); err != nil {
+				return openMPVErrorMsg{err: err}
 			}
 		}
-		return clipboardCompleteMsg("URL copied to clipboard!")
+		entries := make([]internal.PlaylistEntry, len(files))
+		for i, f := range files {
+			entries[i] = internal.PlaylistEntry{ID: f.id, Name: f.title, Start: f.start}
+		}
+		if err := player.Load(entries, true); err != nil {
+			return openMPVErrorMsg{err: err}
+		}
+		text := fmt.Sprintf("Playing %s in mpv", files[0].title)
+		if len(files) > 1 {
+			text = fmt.Sprintf("Playing %d files in mpv", len(files))
+		}
+		return playerStartedMsg{player: player, text: text}
 	}
 }
 
-func cmdOpenMPV(client *seedr.Client, fileID string) tea.Cmd {
+// playerSource fetches download URLs for mpv with a client.
+type playerSource struct{ client *seedr.Client }
+
+func (s playerSource) FileURL(id string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), internal.Conf.RequestTimeout)
+	defer cancel()
+	fileResult, err := s.client.FetchFile(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return fileResult.URL, nil
+}
+
+// waitForPlayer waits for the next change of mpv's state.
+func waitForPlayer(player *internal.MPV) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		state, ok := <-player.Updates()
+		if !ok {
+			return playerClosedMsg{player: player}
+		}
+		return playerStateMsg{player: player, state: state}
+	}
+}
+
+// cmdControlPlayer sends a command such as pause or next to mpv.
+func cmdControlPlayer(control func() error) tea.Cmd {
+	return func() tea.Msg {
+		if err := control(); err != nil {
+			return itemChosenMsg(err.Error())
+		}
+		return nil
+	}
+}
+
+// cmdOpenWith fetches a file's download URL and starts the given handler with it in the background.
+func cmdOpenWith(client *seedr.Client, file item, handler internal.Handler) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), internal.Conf.RequestTimeout)
 		defer cancel()
 
-		fileResult, err := client.FetchFile(ctx, fileID)
+		fileResult, err := client.FetchFile(ctx, file.id)
+		if err != nil {
+			return openMPVErrorMsg{err: fmt.Errorf("failed to get download URL for %s: %w", handler.Name, err)}
+		}
+		vars := internal.HandlerVars{URL: fileResult.URL, Name: file.title, ID: file.id, Start: file.start}
+		if err := handler.Start(vars); err != nil {
+			return openMPVErrorMsg{err: err}
+		}
+		// We don't wait for it to finish, just that it started
+		return openMPVCompleteMsg(fmt.Sprintf("Opening %s with %s...", file.title, handler.Name))
+	}
+}
+
+func cmdMoveItems(client *seedr.Client, items []item, destFolderID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*internal.Conf.RequestTimeout) // Two requests
+		defer cancel()
+
+		refs := make([]seedr.ItemRef, 0, len(items))
+		for _, it := range items {
+			refs = append(refs, seedr.ItemRef{Type: it.itemType.apiType(), ID: it.id})
+		}
+
+		if _, err := client.Move(ctx, refs, destFolderID); err != nil {
+			return moveErrorMsg{err: fmt.Errorf("failed to move %d item(s): %w", len(items), err)}
+		}
+		return moveCompleteMsg(fmt.Sprintf("Moved %d item(s).", len(items)))
+	}
+}
+
+func fetchWishlist(client *seedr.Client) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), internal.Conf.RequestTimeout)
+		defer cancel()
+
+		items, err := client.GetWishlist(ctx)
 		if err != nil {
-			return openMPVErrorMsg{err: fmt.Errorf("failed to get download URL for MPV: %w", err)}
+			return wishlistErrorMsg{err: fmt.Errorf("failed to fetch wishlist: %w", err)}
 		}
+		return wishlistMsg{items: items}
+	}
+}
+
+// fetchRecent lists the partly watched videos of the whole account.
+func fetchRecent(client *seedr.Client) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*internal.Conf.RequestTimeout) // One request per folder
+		defer cancel()
 
-		// Run mpv as a detached process
-		cmd := exec.Command("mpv", fileResult.URL)
-		err = cmd.Start() // Use Start to run in background
+		videos, err := internal.RecentVideos(ctx, client)
 		if err != nil {
-			return openMPVErrorMsg{err: fmt.Errorf("failed to start mpv: %w", err)}
+			return recentErrorMsg{err: fmt.Errorf("failed to list videos to continue watching: %w", err)}
 		}
-		// We don't wait for it to finish, just that it started
-		return openMPVCompleteMsg(fmt.Sprintf("Opening %s with mpv...", fileResult.URL))
+		return recentMsg{videos: videos}
+	}
+}
+
+// cmdPromoteWishlist adds a wishlist item to the root folder if it fits in the free space.
+func cmdPromoteWishlist(client *seedr.Client, wi seedr.WishlistItem) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*internal.Conf.RequestTimeout) // Two requests
+		defer cancel()
+
+		mb, err := client.GetMemoryBandwidth(ctx)
+		if err != nil {
+			return wishlistErrorMsg{err: fmt.Errorf("failed to fetch free space: %w", err)}
+		}
+		if free := mb.SpaceMax - mb.SpaceUsed; wi.Size > free {
+			return wishlistPromotedMsg(fmt.Sprintf("%s needs %s, only %s free", wi.Title, internal.HumanReadableBytes(wi.Size), internal.HumanReadableBytes(max(free, 0))))
+		}
+
+		var magnet *string
+		if wi.Magnet != "" {
+			magnet = &wi.Magnet
+		}
+		wishlistID := fmt.Sprintf("%d", wi.ID)
+		result, err := client.AddTorrent(ctx, magnet, nil, &wishlistID, "-1")
+		if err != nil {
+			return wishlistErrorMsg{err: fmt.Errorf("failed to add %s: %w", wi.Title, err)}
+		}
+		if !result.Result {
+			return wishlistErrorMsg{err: fmt.Errorf("failed to add %s: the API did not accept the torrent", wi.Title)}
+		}
+		return wishlistPromotedMsg(fmt.Sprintf("Added %s from the wishlist", wi.Title))
 	}
 }
 
@@ -215,7 +352,7 @@ func cmdBatchDownloadFiles(client *seedr.Client, files []item) tea.Cmd {
 		go func() {
 			var batchErrors []error
 			for _, file := range files {
-				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second) // Longer timeout per file
+				ctx, cancel := context.WithTimeout(context.Background(), internal.Conf.DownloadTimeout) // Longer timeout per file
 				fileResult, err := client.FetchFile(ctx, file.id)
 				if err != nil {
 					batchErrors = append(batchErrors, downloadErrorMsg{err: fmt.Errorf("failed to get download URL for %s: %w", file.title, err)}.err)
@@ -237,7 +374,7 @@ func cmdBatchDownloadFiles(client *seedr.Client, files []item) tea.Cmd {
 					continue // Move to next file
 				}
 
-				outFile, err := os.Create(file.title)
+				outFile, err := os.Create(filepath.Join(internal.Conf.DownloadDir, file.title))
 				if err != nil {
 					batchErrors = append(batchErrors, downloadErrorMsg{err: fmt.Errorf("failed to create local file %s: %w", file.title, err)}.err)
 					cancel()
@@ -286,3 +423,33 @@ func cmdBatchDownloadFiles(client *seedr.Client, files []item) tea.Cmd {
 		}
 	}
 }
+
+// cmdSwitchProfile loads a client for the next logged-in profile after active, in the order they
+// are listed. It leaves the global account alone; Update makes the switch on profileSwitchedMsg.
+func cmdSwitchProfile(active string) tea.Cmd {
+	return func() tea.Msg {
+		names, err := internal.ListProfiles()
+		if err != nil {
+			return profileErrorMsg{err: err}
+		}
+		start := 0
+		for i, name := range names {
+			if name == active {
+				start = i
+				break
+			}
+		}
+		for i := 1; i < len(names); i++ {
+			name := names[(start+i)%len(names)]
+			if !internal.ProfileLoggedIn(name) {
+				continue
+			}
+			client, err := internal.ProfileClient(name)
+			if err != nil {
+				return profileErrorMsg{err: fmt.Errorf("failed to switch to profile %s: %w", name, err)}
+			}
+			return profileSwitchedMsg{name: name, client: client}
+		}
+		return itemChosenMsg("No other logged-in profile")
+	}
+}
@@ -3,6 +3,7 @@ package tui
 import (
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
@@ -23,8 +24,22 @@ const (
 	TypeFolder itemType = iota
 	TypeFile
 	TypeTorrent
+	TypeWishlist // A torrent queued on the wishlist, shown in the wishlist view
+	TypeHandler  // A program to open a file with, shown in the "open with" menu
 )
 
+// apiType returns the item type name used by the Seedr API.
+func (t itemType) apiType() string {
+	switch t {
+	case TypeFolder:
+		return "folder"
+	case TypeTorrent:
+		return "torrent"
+	default:
+		return "file"
+	}
+}
+
 // item implements the list.Item interface.
 type item struct {
 	id       string
@@ -32,6 +47,7 @@ type item struct {
 	title    string
 	desc     string
 	marked   bool // Add marked field
+	start    time.Duration // Saved playback position of a video
 }
 
 func (i item) FilterValue() string { return i.title }
@@ -127,7 +143,7 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 			currentTitleStyle = currentTitleStyle.Inherit(d.styles.FolderTitle)
 		case TypeFile:
 			currentTitleStyle = currentTitleStyle.Inherit(d.styles.FileTitle)
-		case TypeTorrent:
+		case TypeTorrent, TypeWishlist:
 			currentTitleStyle = currentTitleStyle.Inherit(d.styles.TorrentTitle)
 		}
 	}
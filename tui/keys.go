@@ -12,7 +12,15 @@ type KeyMap struct {
 	Download key.Binding
 	CopyURL  key.Binding
 	OpenMPV  key.Binding
+	OpenWith key.Binding
+	Pause    key.Binding
+	Next     key.Binding
 	Mark     key.Binding
+	Cut      key.Binding
+	Paste    key.Binding
+	Wishlist key.Binding
+	Recent   key.Binding
+	Profile  key.Binding
 	Retry    key.Binding
 	Enter    key.Binding
 	Back     key.Binding
@@ -35,9 +43,17 @@ func (k KeyMap) ShortHelp() []key.Binding {
 		k.Back,
 		k.Download,
 		k.Mark,
+		k.Cut,
+		k.Paste,
+		k.Wishlist,
+		k.Recent,
+		k.Profile,
 		k.Retry,
 		k.CopyURL,
 		k.OpenMPV,
+		k.OpenWith,
+		k.Pause,
+		k.Next,
 		k.Filter,
 		// list-fancy keys for short help
 		k.ToggleHelpMenu,
@@ -48,7 +64,7 @@ func (k KeyMap) ShortHelp() []key.Binding {
 // more detailed help menu.
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Quit, k.Enter, k.Back, k.Download, k.Mark, k.Retry, k.CopyURL, k.OpenMPV},
+		{k.Quit, k.Enter, k.Back, k.Download, k.Mark, k.Cut, k.Paste, k.Wishlist, k.Recent, k.Profile, k.Retry, k.CopyURL, k.OpenMPV, k.OpenWith, k.Pause, k.Next},
 		{k.CursorUp, k.CursorDown, k.GoToStart, k.GoToEnd},
 		{k.Filter, k.ClearFilter, k.CancelWhileFiltering, k.AcceptWhileFiltering},
 		// list-fancy keys for full help
@@ -74,10 +90,42 @@ var DefaultKeyMap = KeyMap{
 		key.WithKeys("o"),
 		key.WithHelp("o", "open MPV"),
 	),
+	OpenWith: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "open with…"),
+	),
+	Pause: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "pause mpv"),
+	),
+	Next: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next in mpv"),
+	),
 	Mark: key.NewBinding(
 		key.WithKeys("m"),
 		key.WithHelp("m", "mark/unmark"),
 	),
+	Cut: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "cut"),
+	),
+	Paste: key.NewBinding(
+		key.WithKeys("V"),
+		key.WithHelp("V", "paste here"),
+	),
+	Wishlist: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "wishlist"),
+	),
+	Recent: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "continue watching"),
+	),
+	Profile: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "switch profile"),
+	),
 	Retry: key.NewBinding(
 		key.WithKeys("r"),
 		key.WithHelp("r", "retry"),
@@ -2,6 +2,9 @@ package tui
 
 import (
 	"github.com/charmbracelet/bubbles/list"
+
+	"seedr/internal"
+	"seedr/pkg/seedr"
 )
 
 // MESSAGES
@@ -14,6 +17,18 @@ type clipboardCompleteMsg string
 type clipboardErrorMsg struct{ err error }
 type openMPVCompleteMsg string
 type openMPVErrorMsg struct{ err error }
+type playerStartedMsg struct{ player *internal.MPV; text string }
+type playerStateMsg struct{ player *internal.MPV; state internal.PlayerState }
+type playerClosedMsg struct{ player *internal.MPV }
+type moveCompleteMsg string
+type moveErrorMsg struct{ err error }
+type wishlistMsg struct{ items []seedr.WishlistItem }
+type wishlistPromotedMsg string
+type wishlistErrorMsg struct{ err error }
+type recentMsg struct{ videos []internal.RecentVideo }
+type recentErrorMsg struct{ err error }
+type profileSwitchedMsg struct{ name string; client *seedr.Client }
+type profileErrorMsg struct{ err error }
 type batchDownloadCompleteMsg string
 type batchDownloadErrorMsg struct{ err error }
 
@@ -24,4 +39,8 @@ func (e errMsg) Error() string { return e.err.Error() }
 func (e downloadErrorMsg) Error() string { return e.err.Error() }
 func (e clipboardErrorMsg) Error() string { return e.err.Error() }
 func (e openMPVErrorMsg) Error() string { return e.err.Error() }
+func (e moveErrorMsg) Error() string { return e.err.Error() }
+func (e wishlistErrorMsg) Error() string { return e.err.Error() }
+func (e recentErrorMsg) Error() string { return e.err.Error() }
+func (e profileErrorMsg) Error() string { return e.err.Error() }
 func (e batchDownloadErrorMsg) Error() string { return e.err.Error() }
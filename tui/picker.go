@@ -0,0 +1,204 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"seedr/internal"
+	"seedr/pkg/seedr"
+)
+
+// pickerPreviewFiles is the number of file names shown under each scanned torrent.
+const pickerPreviewFiles = 3
+
+// pickerItem is a scanned torrent in the picker list.
+type pickerItem struct {
+	torrent  seedr.ScannedTorrent
+	selected bool
+}
+
+func (i pickerItem) FilterValue() string { return i.torrent.Title }
+
+// pickerDelegate renders scanned torrents with their size, health and a file preview.
+type pickerDelegate struct {
+	styles MyItemStyles
+}
+
+func (d pickerDelegate) Height() int                             { return 3 }
+func (d pickerDelegate) Spacing() int                            { return 1 }
+func (d pickerDelegate) Update(msg tea.Msg, m *list.Model) tea.Cmd { return nil }
+
+func (d pickerDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
+	i, ok := listItem.(pickerItem)
+	if !ok || m.Width() <= 0 {
+		return
+	}
+
+	isSelected := index == m.Index() && m.FilterState() != list.Filtering
+	titleStyle, descStyle := d.styles.NormalTitle, d.styles.NormalDesc
+	if isSelected {
+		titleStyle, descStyle = d.styles.SelectedTitle, d.styles.SelectedDesc
+	} else {
+		titleStyle = titleStyle.Inherit(d.styles.TorrentTitle)
+	}
+
+	check := "[ ] "
+	if i.selected {
+		check = "[x] "
+	}
+	title := check + i.torrent.Title
+	meta := fmt.Sprintf("Size: %s | Health: %.0f%% | Files: %d", internal.HumanReadableBytes(i.torrent.Size), i.torrent.Pct, len(i.torrent.Filenames))
+
+	var preview []string
+	for n, name := range i.torrent.Filenames {
+		if n == pickerPreviewFiles {
+			preview = append(preview, fmt.Sprintf("+%d more", len(i.torrent.Filenames)-n))
+			break
+		}
+		if n < len(i.torrent.Filesizes) {
+			name = fmt.Sprintf("%s (%s)", name, internal.HumanReadableBytes(i.torrent.Filesizes[n]))
+		}
+		preview = append(preview, name)
+	}
+	files := strings.Join(preview, ", ")
+
+	textWidth := m.Width() - titleStyle.GetPaddingLeft() - titleStyle.GetPaddingRight()
+	if textWidth < 0 {
+		textWidth = 0
+	}
+	title = ansi.Truncate(title, textWidth, ellipsis)
+	meta = ansi.Truncate(meta, textWidth, ellipsis)
+	files = ansi.Truncate(files, textWidth, ellipsis)
+
+	fmt.Fprintf(w, "%s\n%s\n%s", titleStyle.Render(title), descStyle.Render(meta), descStyle.Render(files)) //nolint: errcheck
+}
+
+// pickerKeyMap defines the keys specific to the torrent picker.
+type pickerKeyMap struct {
+	Toggle  key.Binding
+	Confirm key.Binding
+	Cancel  key.Binding
+}
+
+var defaultPickerKeys = pickerKeyMap{
+	Toggle: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "select"),
+	),
+	Confirm: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "add selected"),
+	),
+	Cancel: key.NewBinding(
+		key.WithKeys("q", "esc", "ctrl+c"),
+		key.WithHelp("q", "cancel"),
+	),
+}
+
+// pickerModel is a multi-select list of scanned torrents.
+type pickerModel struct {
+	list      list.Model
+	keys      pickerKeyMap
+	confirmed bool
+}
+
+func newPickerModel(torrents []seedr.ScannedTorrent) pickerModel {
+	items := make([]list.Item, 0, len(torrents))
+	for _, t := range torrents {
+		items = append(items, pickerItem{torrent: t})
+	}
+
+	l := list.New(items, pickerDelegate{styles: NewMyItemStyles()}, 0, 0)
+	l.Title = "Select torrents to add"
+	l.Styles.Title = TitleStyle
+	l.SetShowStatusBar(true)
+	l.SetFilteringEnabled(true)
+	l.DisableQuitKeybindings() // Quitting is handled as a cancel below
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{defaultPickerKeys.Toggle, defaultPickerKeys.Confirm, defaultPickerKeys.Cancel}
+	}
+
+	return pickerModel{list: l, keys: defaultPickerKeys}
+}
+
+func (m pickerModel) Init() tea.Cmd { return nil }
+
+func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		h, v := AppStyle.GetFrameSize()
+		m.list.SetSize(msg.Width-h, msg.Height-v)
+		return m, nil
+
+	case tea.KeyMsg:
+		// Let the filter input receive every key while filtering.
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch {
+		case key.Matches(msg, m.keys.Toggle):
+			if selected, ok := m.list.SelectedItem().(pickerItem); ok {
+				selected.selected = !selected.selected
+				cmd := m.list.SetItem(m.list.GlobalIndex(), selected) // SetItem indexes all items, not just the filtered ones
+				return m, cmd
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.Confirm):
+			m.confirmed = true
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Cancel):
+			if msg.String() == "esc" && m.list.FilterState() == list.FilterApplied {
+				break // Esc clears an applied filter first
+			}
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m pickerModel) View() string {
+	if m.confirmed {
+		return ""
+	}
+	return AppStyle.Render(m.list.View())
+}
+
+// selectedTorrents returns the selected torrents; the highlighted one if none were selected.
+func (m pickerModel) selectedTorrents() []seedr.ScannedTorrent {
+	var chosen []seedr.ScannedTorrent
+	for _, listItem := range m.list.Items() {
+		if it, ok := listItem.(pickerItem); ok && it.selected {
+			chosen = append(chosen, it.torrent)
+		}
+	}
+	if len(chosen) == 0 {
+		if it, ok := m.list.SelectedItem().(pickerItem); ok {
+			chosen = append(chosen, it.torrent)
+		}
+	}
+	return chosen
+}
+
+// PickTorrents shows an interactive multi-select list of scanned torrents.
+// It returns the chosen torrents, or nil if the user cancelled.
+func PickTorrents(torrents []seedr.ScannedTorrent) ([]seedr.ScannedTorrent, error) {
+	p := tea.NewProgram(newPickerModel(torrents), tea.WithAltScreen())
+	final, err := p.Run()
+	if err != nil {
+		return nil, fmt.Errorf("error running picker: %w", err)
+	}
+	m, ok := final.(pickerModel)
+	if !ok || !m.confirmed {
+		return nil, nil
+	}
+	return m.selectedTorrents(), nil
+}
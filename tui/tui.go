@@ -2,6 +2,8 @@ package tui
 
 import (
 	"fmt"
+	"path"
+	"sort"
 	"strings"
 	"time" // Import time package
 
@@ -49,6 +51,15 @@ type model struct {
 	currentFolderID string
 	contentCache    map[string]contentsMsg
 	markedFiles     map[string]item // Map to store marked files by their ID
+	cutItems        []item // Items waiting to be pasted into another folder
+	cutFromFolderID string // Folder the cut items were taken from
+	showingWishlist bool // The list shows wishlist items instead of the current folder
+	wishlist        map[string]seedr.WishlistItem // Wishlist items by ID, while showingWishlist
+	showingRecent   bool // The list shows partly watched videos instead of the current folder
+	showingHandlers bool // The list shows the "open with" menu for openWithFile
+	openWithFile    item
+	player          *internal.MPV // mpv controlled over IPC, once something was played
+	playing         internal.PlayerState
 	currentFolderPath string // Stores the current folder's path in a Linux-like format
 	chosenMessage   string // New field to display messages below the title
 	originalTitle   string // Stores the base title without the chosenMessage
@@ -74,7 +85,15 @@ func newModel(client *seedr.Client) model {
 			DefaultKeyMap.Download,
 			DefaultKeyMap.CopyURL,
 			DefaultKeyMap.OpenMPV,
+			DefaultKeyMap.OpenWith,
+			DefaultKeyMap.Pause,
+			DefaultKeyMap.Next,
 			DefaultKeyMap.Mark,
+			DefaultKeyMap.Cut,
+			DefaultKeyMap.Paste,
+			DefaultKeyMap.Wishlist,
+			DefaultKeyMap.Recent,
+			DefaultKeyMap.Profile,
 			DefaultKeyMap.Retry,
 			DefaultKeyMap.ToggleSpinner,
 			DefaultKeyMap.ToggleTitleBar,
@@ -132,13 +151,76 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		var cmd tea.Cmd
+		if m.showingHandlers {
+			switch {
+			case key.Matches(msg, m.keys.Quit):
+				m.quitting = true
+				return m, tea.Quit
+			case key.Matches(msg, m.keys.Enter):
+				selectedItem := m.list.SelectedItem()
+				if selectedItem == nil {
+					return m, nil
+				}
+				handler, err := internal.FindHandler(selectedItem.(item).id)
+				if err != nil {
+					return m, m.list.NewStatusMessage(StatusMessageStyle(err.Error()))
+				}
+				file := m.openWithFile
+				m.leaveHandlers()
+				m.state = stateLoading
+				return m, tea.Batch(m.spinner.Tick, cmdOpenWith(m.client, file, handler))
+			case key.Matches(msg, m.keys.Back), msg.String() == "esc":
+				m.leaveHandlers()
+				return m, nil
+			}
+			m.list, cmd = m.list.Update(msg)
+			return m, cmd
+		}
+
 		switch {
 		// General Keys (Seedr-specific)
 		case key.Matches(msg, m.keys.Quit):
 			m.quitting = true
 			return m, tea.Quit
 
+		case key.Matches(msg, m.keys.Wishlist):
+			if m.showingWishlist {
+				return m, m.leaveWishlist()
+			}
+			if (m.state == stateReady || m.state == stateEmpty) && !m.showingRecent {
+				m.showingWishlist = true
+				m.state = stateLoading
+				m.updateListTitle()
+				return m, tea.Batch(m.spinner.Tick, fetchWishlist(m.client))
+			}
+
+		case key.Matches(msg, m.keys.Recent):
+			if m.showingRecent {
+				return m, m.leaveRecent()
+			}
+			if (m.state == stateReady || m.state == stateEmpty) && !m.showingWishlist {
+				m.showingRecent = true
+				m.state = stateLoading
+				m.updateListTitle()
+				return m, tea.Batch(m.spinner.Tick, fetchRecent(m.client))
+			}
+
+		case key.Matches(msg, m.keys.Profile):
+			if m.state != stateLoading && m.state != stateDownloading {
+				return m, cmdSwitchProfile(internal.ActiveProfile())
+			}
+
 		case key.Matches(msg, m.keys.Retry):
+			if m.showingWishlist && m.state == stateError {
+				m.state = stateLoading
+				m.err = nil
+				return m, tea.Batch(m.spinner.Tick, fetchWishlist(m.client))
+			}
+			if m.showingRecent && m.state == stateError {
+				m.state = stateLoading
+				m.err = nil
+				return m, tea.Batch(m.spinner.Tick, fetchRecent(m.client))
+			}
 			if m.state == stateError || m.state == stateEmpty {
 				m.state = stateLoading
 				m.err = nil
@@ -148,6 +230,26 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case key.Matches(msg, m.keys.Enter):
+			if m.showingWishlist && m.state == stateReady {
+				selectedItem := m.list.SelectedItem()
+				if selectedItem == nil {
+					return m, nil
+				}
+				wi, ok := m.wishlist[selectedItem.(item).id]
+				if !ok {
+					return m, nil
+				}
+				m.state = stateLoading
+				return m, tea.Batch(m.spinner.Tick, cmdPromoteWishlist(m.client, wi))
+			}
+			if m.showingRecent && m.state == stateReady {
+				selectedItem := m.list.SelectedItem()
+				if selectedItem == nil {
+					return m, nil
+				}
+				m.state = stateLoading
+				return m, tea.Batch(m.spinner.Tick, cmdOpenMPV(m.player, m.client, selectedItem.(item)))
+			}
 			if m.state == stateReady {
 				selectedItem := m.list.SelectedItem()
 				if selectedItem == nil {
@@ -181,6 +283,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case key.Matches(msg, m.keys.Back):
+			if m.showingWishlist {
+				return m, m.leaveWishlist()
+			}
+			if m.showingRecent {
+				return m, m.leaveRecent()
+			}
 			if m.state == stateReady && len(m.folderHistory) > 1 {
 				internal.Log.Debug("Back key pressed. Current Folder ID: %s, History: %v", m.currentFolderID, m.folderHistory)
 
@@ -248,6 +356,38 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
+		case key.Matches(msg, m.keys.Cut):
+			if m.state == stateReady && !m.showingWishlist && !m.showingRecent {
+				var toCut []item
+				if len(m.markedFiles) > 0 {
+					for _, markedFile := range m.markedFiles {
+						markedFile.marked = false
+						toCut = append(toCut, markedFile)
+					}
+					m.clearMarks()
+				} else if selectedItem := m.list.SelectedItem(); selectedItem != nil {
+					toCut = append(toCut, selectedItem.(item))
+				}
+				if len(toCut) == 0 {
+					return m, nil
+				}
+				m.cutItems = toCut
+				m.cutFromFolderID = m.currentFolderID
+				return m, m.list.NewStatusMessage(StatusMessageStyle(fmt.Sprintf("Cut %d item(s), press V in the destination folder to paste", len(toCut))))
+			}
+
+		case key.Matches(msg, m.keys.Paste):
+			if (m.state == stateReady || m.state == stateEmpty) && !m.showingWishlist && !m.showingRecent {
+				if len(m.cutItems) == 0 {
+					return m, m.list.NewStatusMessage(StatusMessageStyle("Nothing to paste"))
+				}
+				if m.cutFromFolderID == m.currentFolderID {
+					return m, m.list.NewStatusMessage(StatusMessageStyle("Items are already in this folder"))
+				}
+				m.state = stateLoading
+				return m, tea.Batch(m.spinner.Tick, cmdMoveItems(m.client, m.cutItems, m.currentFolderID))
+			}
+
 		case key.Matches(msg, m.keys.Download):
 			if m.state == stateReady {
 				if len(m.markedFiles) > 0 {
@@ -294,7 +434,17 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.OpenMPV):
 			if m.state == stateReady {
 				if len(m.markedFiles) > 0 {
-					return m, m.list.NewStatusMessage(StatusMessageStyle("Cannot open with MPV when files are marked for batch operations"))
+					// Queue the marked videos and songs as one playlist
+					files := m.markedPlaylist()
+					if len(files) == 0 {
+						return m, m.list.NewStatusMessage(StatusMessageStyle("None of the marked files can be played"))
+					}
+					if !internal.IsMPV(internal.Conf.Player) {
+						return m, m.list.NewStatusMessage(StatusMessageStyle("Playing marked files needs mpv as player.command"))
+					}
+					m.clearMarks()
+					m.state = stateLoading
+					return m, tea.Batch(m.spinner.Tick, cmdQueueMPV(m.player, m.client, files))
 				}
 				selectedItem := m.list.SelectedItem()
 				if selectedItem == nil {
@@ -303,7 +453,29 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				item := selectedItem.(item)
 				if item.itemType == TypeFile {
 					m.state = stateLoading // Show spinner
-					return m, tea.Batch(m.spinner.Tick, cmdOpenMPV(m.client, item.id))
+					return m, tea.Batch(m.spinner.Tick, cmdOpenMPV(m.player, m.client, item))
+				}
+			}
+		case key.Matches(msg, m.keys.Pause):
+			if m.player != nil {
+				return m, cmdControlPlayer(m.player.TogglePause)
+			}
+		case key.Matches(msg, m.keys.Next):
+			if m.player != nil {
+				return m, cmdControlPlayer(m.player.Next)
+			}
+		case key.Matches(msg, m.keys.OpenWith):
+			if m.state == stateReady && !m.showingWishlist && !m.showingRecent {
+				if len(m.markedFiles) > 0 {
+					return m, m.list.NewStatusMessage(StatusMessageStyle("Cannot open a file when files are marked for batch operations"))
+				}
+				selectedItem := m.list.SelectedItem()
+				if selectedItem == nil {
+					return m, nil
+				}
+				if item := selectedItem.(item); item.itemType == TypeFile {
+					m.showHandlers(item)
+					return m, nil
 				}
 			}
 
@@ -347,6 +519,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 
 	case contentsMsg:
+		if m.showingWishlist || m.showingRecent {
+			// A folder listing finished after switching to another view; keep it for later.
+			m.contentCache[m.currentFolderID] = msg
+			return m, nil
+		}
 		m.state = stateReady
 		m.list.SetItems(msg.items)
 		// Set title to current path, which is updated on enter/backspace
@@ -380,6 +557,112 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.err = msg.err
 		return m, nil
 
+	case moveCompleteMsg:
+		// Both folders changed; drop their cached listings and reload the current one
+		delete(m.contentCache, m.cutFromFolderID)
+		delete(m.contentCache, m.currentFolderID)
+		m.cutItems = nil
+		m.cutFromFolderID = ""
+		m.state = stateLoading
+		m.err = nil
+		return m, tea.Batch(m.spinner.Tick, fetchContents(m.client, m.currentFolderID), m.list.NewStatusMessage(string(msg)))
+	case moveErrorMsg:
+		m.state = stateError
+		m.err = msg.err
+		return m, nil
+
+	case wishlistMsg:
+		if !m.showingWishlist {
+			return m, nil
+		}
+		m.wishlist = make(map[string]seedr.WishlistItem, len(msg.items))
+		items := make([]list.Item, 0, len(msg.items))
+		for _, wi := range msg.items {
+			id := fmt.Sprintf("%d", wi.ID)
+			m.wishlist[id] = wi
+			added := "N/A"
+			if wi.Added != nil {
+				added = wi.Added.Format("2006-01-02 15:04:05")
+			}
+			items = append(items, item{
+				id:       id,
+				itemType: TypeWishlist,
+				title:    wi.Title,
+				desc:     fmt.Sprintf("Wishlist | Size: %s | Added: %s | enter to add", internal.HumanReadableBytes(wi.Size), added),
+			})
+		}
+		m.state = stateReady
+		m.err = nil
+		m.list.SetItems(items)
+		m.list.Select(0)
+		m.updateListTitle()
+		return m, nil
+	case wishlistPromotedMsg:
+		// A promoted torrent shows up in the root folder and leaves the wishlist
+		delete(m.contentCache, "0")
+		m.state = stateLoading
+		return m, tea.Batch(m.spinner.Tick, fetchWishlist(m.client), m.list.NewStatusMessage(StatusMessageStyle(string(msg))))
+	case wishlistErrorMsg:
+		m.state = stateError
+		m.err = msg.err
+		return m, nil
+
+	case recentMsg:
+		if !m.showingRecent {
+			return m, nil
+		}
+		items := make([]list.Item, 0, len(msg.videos))
+		for _, v := range msg.videos {
+			updated := "N/A"
+			if v.File.LastUpdate != nil {
+				updated = v.File.LastUpdate.Format("2006-01-02 15:04:05")
+			}
+			items = append(items, item{
+				id:       fmt.Sprintf("%d", v.File.FolderFileID),
+				itemType: TypeFile,
+				title:    v.File.Name,
+				desc:     fmt.Sprintf("Watched to %s | %s | Last Update: %s | enter to resume", internal.FormatPosition(v.Position), path.Dir(v.Path), updated),
+				start:    v.Position,
+			})
+		}
+		m.state = stateReady
+		m.err = nil
+		m.list.SetItems(items)
+		m.list.Select(0)
+		m.updateListTitle()
+		return m, nil
+	case recentErrorMsg:
+		m.state = stateError
+		m.err = msg.err
+		return m, nil
+
+	case profileSwitchedMsg:
+		// Nothing from the previous account applies to the new one; start over at its root folder
+		internal.SetActiveProfile(msg.name, msg.client)
+		m.client = msg.client
+		if m.player != nil {
+			m.player.SetSource(playerSource{m.client})
+		}
+		m.folderHistory = []string{"0"}
+		m.currentFolderID = "0"
+		m.currentFolderPath = "/"
+		m.contentCache = make(map[string]contentsMsg)
+		m.markedFiles = make(map[string]item)
+		m.cutItems = nil
+		m.cutFromFolderID = ""
+		m.showingWishlist = false
+		m.wishlist = nil
+		m.showingRecent = false
+		m.err = nil
+		m.state = stateLoading
+		m.updateListTitle()
+		m.list.Select(0)
+		return m, tea.Batch(m.spinner.Tick, fetchContents(m.client, m.currentFolderID), m.list.NewStatusMessage(StatusMessageStyle("Switched to profile "+msg.name)))
+	case profileErrorMsg:
+		m.state = stateError
+		m.err = msg.err
+		return m, nil
+
 	case batchDownloadCompleteMsg:
 		m.state = stateReady
 		m.err = nil
@@ -404,6 +687,29 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.state = stateReady // Return to ready state
 		m.err = nil
 		return m, m.list.NewStatusMessage(string(msg))
+	case playerStartedMsg:
+		m.state = stateReady
+		m.err = nil
+		cmds := []tea.Cmd{m.list.NewStatusMessage(msg.text)}
+		if m.player != msg.player {
+			m.player = msg.player
+			m.playing = msg.player.State()
+			cmds = append(cmds, waitForPlayer(m.player))
+		}
+		return m, tea.Batch(cmds...)
+	case playerStateMsg:
+		if msg.player != m.player {
+			return m, nil
+		}
+		m.playing = msg.state
+		return m, waitForPlayer(m.player)
+	case playerClosedMsg:
+		if msg.player == m.player {
+			// mpv was closed; the next file played starts a new one
+			m.player = nil
+			m.playing = internal.PlayerState{}
+		}
+		return m, nil
 	case openMPVErrorMsg:
 		m.state = stateError
 		m.err = msg.err
@@ -445,6 +751,9 @@ func (m model) View() string {
 		if m.chosenMessage != "" {
 			s.WriteString("\n" + StatusMessageStyle(m.chosenMessage))
 		}
+		if nowPlaying := m.nowPlaying(); nowPlaying != "" {
+			s.WriteString("\n" + StatusMessageStyle(nowPlaying))
+		}
 		viewString = s.String()
 	case stateEmpty:
 		viewString = "No contents Found in this Folder.\n\nPress 'r' to retry, 'backspace' to go back, 'q' to quit."
@@ -457,15 +766,128 @@ func (m model) View() string {
 // RunTUI is the exported function to start the TUI.
 func RunTUI(client *seedr.Client) error {
 	p := tea.NewProgram(newModel(client), tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	finalModel, err := p.Run()
+	if m, ok := finalModel.(model); ok && m.player != nil {
+		m.player.Close() // mpv keeps playing; save how far it got
+	}
+	if err != nil {
 		return fmt.Errorf("error running program: %w", err)
 	}
 	return nil
 }
 
+// clearMarks unmarks every marked file in the current list.
+func (m *model) clearMarks() {
+	items := m.list.Items()
+	for i, listItem := range items {
+		if it, ok := listItem.(item); ok && it.marked {
+			it.marked = false
+			items[i] = it
+		}
+	}
+	m.list.SetItems(items)
+	m.markedFiles = make(map[string]item)
+}
+
+// leaveWishlist switches from the wishlist back to the current folder.
+func (m *model) leaveWishlist() tea.Cmd {
+	m.showingWishlist = false
+	m.wishlist = nil
+	return m.showCurrentFolder()
+}
+
+// leaveRecent switches from the videos to continue watching back to the current folder.
+func (m *model) leaveRecent() tea.Cmd {
+	m.showingRecent = false
+	return m.showCurrentFolder()
+}
+
+// showCurrentFolder shows the current folder again, from the cache if it was listed before.
+func (m *model) showCurrentFolder() tea.Cmd {
+	m.err = nil
+	m.updateListTitle()
+	if cachedContents, ok := m.contentCache[m.currentFolderID]; ok {
+		m.state = stateReady
+		m.list.SetItems(cachedContents.items)
+		m.list.Select(0)
+		return nil
+	}
+	m.state = stateLoading
+	return tea.Batch(m.spinner.Tick, fetchContents(m.client, m.currentFolderID))
+}
+
+// markedPlaylist returns the marked videos and songs in natural order of their names.
+func (m *model) markedPlaylist() []item {
+	var files []item
+	for _, f := range m.markedFiles {
+		if t := internal.FileType(f.title); t == "video" || t == "audio" {
+			files = append(files, f)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return internal.NaturalLess(files[i].title, files[j].title) })
+	return files
+}
+
+// nowPlaying describes what mpv is playing, or returns "" if it is not playing anything.
+func (m *model) nowPlaying() string {
+	if m.player == nil || m.playing.Idle || m.playing.Title == "" {
+		return ""
+	}
+	icon := "▶"
+	if m.playing.Paused {
+		icon = "⏸"
+	}
+	s := icon + " " + m.playing.Title
+	if m.playing.Count > 1 && m.playing.Pos >= 0 {
+		s += fmt.Sprintf(" (%d/%d)", m.playing.Pos+1, m.playing.Count)
+	}
+	return s
+}
+
+// showHandlers replaces the list with the "open with" menu for a file.
+func (m *model) showHandlers(file item) {
+	m.showingHandlers = true
+	m.openWithFile = file
+	var items []list.Item
+	for _, h := range internal.Handlers() {
+		items = append(items, item{id: h.Name, itemType: TypeHandler, title: h.Name, desc: h.Template})
+	}
+	m.list.ResetFilter()
+	m.list.SetItems(items)
+	m.list.Select(0)
+	m.updateListTitle()
+}
+
+// leaveHandlers switches from the "open with" menu back to the current folder.
+func (m *model) leaveHandlers() {
+	m.showingHandlers = false
+	m.updateListTitle()
+	if cachedContents, ok := m.contentCache[m.currentFolderID]; ok {
+		m.list.SetItems(cachedContents.items)
+	}
+	for i, listItem := range m.list.Items() {
+		if listItem.(item).id == m.openWithFile.id {
+			m.list.Select(i)
+			break
+		}
+	}
+}
+
 // updateListTitle constructs and sets the list's title based on current path and chosen message.
 func (m *model) updateListTitle() {
 	title := "SEEDR" + " " + m.currentFolderPath
+	if m.showingWishlist {
+		title = "SEEDR wishlist"
+	}
+	if m.showingRecent {
+		title = "SEEDR continue watching"
+	}
+	if m.showingHandlers {
+		title = "SEEDR open " + m.openWithFile.title + " with…"
+	}
+	if profile := internal.ActiveProfile(); profile != internal.DefaultProfile {
+		title += " [" + profile + "]"
+	}
 	if m.chosenMessage != "" {
 		m.list.Title = TitleStyle.Render(title) + "\n" + StatusMessageStyle(m.chosenMessage)
 	} else {